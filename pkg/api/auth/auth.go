@@ -0,0 +1,215 @@
+// Package auth implements bearer-token authentication and authorization for
+// pkg/api's REST server: a settings-backed store of hashed API tokens, an
+// Echo middleware that enforces required scopes, and a one-time bootstrap
+// token printed to stderr on first launch.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+	"github.com/labstack/echo/v4"
+)
+
+// Scope gates access to a slice of the REST API. A token may hold any
+// combination of scopes; a request is allowed only if its token holds every
+// scope the matched route requires.
+type Scope string
+
+const (
+	ScopeMCPRead       Scope = "mcp:read"
+	ScopeMCPWrite      Scope = "mcp:write"
+	ScopeSettingsRead  Scope = "settings:read"
+	ScopeSettingsAdmin Scope = "settings:admin"
+)
+
+// Store manages API tokens on top of the settings service's persistence.
+type Store struct {
+	settingsService *settings.SettingsService
+}
+
+// NewStore returns a Store backed by settingsService.
+func NewStore(settingsService *settings.SettingsService) *Store {
+	return &Store{settingsService: settingsService}
+}
+
+// Create generates a new random token with the given name and scopes, saves
+// its hash, and returns the raw secret. The secret is returned exactly once;
+// it cannot be recovered from the store afterward.
+func (s *Store) Create(name string, scopes []Scope) (secret string, token settings.APIToken, err error) {
+	secret, err = generateSecret()
+	if err != nil {
+		return "", settings.APIToken{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	id, err := generateSecret()
+	if err != nil {
+		return "", settings.APIToken{}, fmt.Errorf("failed to generate token id: %w", err)
+	}
+
+	token = settings.APIToken{
+		ID:          id[:16],
+		Name:        name,
+		HashedToken: hashToken(secret),
+		Scopes:      scopesToStrings(scopes),
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.settingsService.AddAPIToken(token); err != nil {
+		return "", settings.APIToken{}, err
+	}
+	return secret, token, nil
+}
+
+// List returns every registered token (hashes only, never raw secrets).
+func (s *Store) List() []settings.APIToken {
+	return s.settingsService.GetAPITokens()
+}
+
+// Revoke deletes the token with the given id.
+func (s *Store) Revoke(id string) error {
+	return s.settingsService.RemoveAPIToken(id)
+}
+
+// Authenticate looks up the token matching secret and reports whether it
+// holds every scope in required. Comparison is constant-time per candidate
+// to avoid leaking hash-prefix timing.
+func (s *Store) Authenticate(secret string, required ...Scope) (settings.APIToken, bool) {
+	hashed := hashToken(secret)
+	for _, token := range s.settingsService.GetAPITokens() {
+		if subtle.ConstantTimeCompare([]byte(hashed), []byte(token.HashedToken)) == 1 {
+			return token, hasScopes(token.Scopes, required)
+		}
+	}
+	return settings.APIToken{}, false
+}
+
+// IsEmpty reports whether no tokens have been created yet.
+func (s *Store) IsEmpty() bool {
+	return len(s.settingsService.GetAPITokens()) == 0
+}
+
+// EnsureBootstrapToken creates an admin-scoped token and prints it to stderr
+// if the store has none yet, so a fresh install has a way to authenticate
+// before any token exists to create one with.
+func EnsureBootstrapToken(store *Store) error {
+	if !store.IsEmpty() {
+		return nil
+	}
+
+	secret, _, err := store.Create("bootstrap", []Scope{ScopeMCPRead, ScopeMCPWrite, ScopeSettingsRead, ScopeSettingsAdmin})
+	if err != nil {
+		return fmt.Errorf("failed to create bootstrap token: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "mcp-bouncer: no API tokens configured; created a one-time admin token:\n\n  %s\n\nStore it securely; it will not be shown again.\n", secret)
+	return nil
+}
+
+// hasScopes reports whether granted holds every scope in required.
+func hasScopes(granted []string, required []Scope) bool {
+	for _, req := range required {
+		found := false
+		for _, g := range granted {
+			if g == string(req) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func scopesToStrings(scopes []Scope) []string {
+	out := make([]string, len(scopes))
+	for i, s := range scopes {
+		out[i] = string(s)
+	}
+	return out
+}
+
+// generateSecret returns a random 32-byte value hex-encoded, used both for
+// token secrets and token ids.
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func hashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Middleware validates the Authorization: Bearer <token> header against
+// store, rejecting the request unless the token holds every scope in
+// required. Requests from a loopback address are always let through
+// unauthenticated ("loopback-only" mode, the default), since the bundled
+// desktop UI talks to the API over 127.0.0.1 and predates tokens entirely;
+// only non-loopback callers are required to authenticate. A request carrying
+// a PeerIdentity (TLSIdentityMiddleware's output, i.e. a client certificate
+// Go's TLS stack has already verified against the configured client CA pool)
+// has that identity recorded for logging/tracing, but the CA verifying a
+// certificate only proves who the caller is, not what it's allowed to do —
+// it still has to present a bearer token holding every scope in required,
+// same as any other non-loopback caller.
+func Middleware(store *Store, required ...Scope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if isLoopback(c.Request()) {
+				return next(c)
+			}
+
+			if identity, ok := PeerIdentityFromContext(c.Request().Context()); ok {
+				c.Set("peer_identity", identity)
+			}
+
+			auth := c.Request().Header.Get("Authorization")
+			secret, ok := strings.CutPrefix(auth, "Bearer ")
+			if !ok || secret == "" {
+				return c.String(http.StatusUnauthorized, "missing bearer token")
+			}
+
+			token, ok := store.Authenticate(secret, required...)
+			if !ok {
+				return c.String(http.StatusForbidden, "token missing required scope")
+			}
+
+			c.Set("api_token", token)
+			return next(c)
+		}
+	}
+}
+
+// isLoopback reports whether r originated from a loopback address.
+func isLoopback(r *http.Request) bool {
+	return IsLoopbackAddr(r.RemoteAddr)
+}
+
+// IsLoopbackAddr reports whether addr (a "host:port" or bare host) is a
+// loopback address. Exported so other transports sharing this package's
+// loopback-only auth bypass - pkg/api's gRPC server, alongside its REST
+// routes - stay in lock-step with this rule instead of keeping their own
+// copy of it.
+func IsLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
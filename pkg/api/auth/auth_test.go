@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+	"github.com/labstack/echo/v4"
+)
+
+// withPeerIdentityRequest returns a non-loopback request carrying identity in
+// its context, as TLSIdentityMiddleware would populate it from a verified
+// client certificate.
+func withPeerIdentityRequest(identity PeerIdentity) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/tokens", nil)
+	req.RemoteAddr = "203.0.113.1:12345"
+	req = req.WithContext(withPeerIdentity(req.Context(), identity))
+	return req
+}
+
+// TestMiddlewareClientCertDoesNotBypassScopes covers chunk4-6's mTLS
+// identity path: a verified client certificate identifies the caller, but
+// must not by itself satisfy a settings:admin-scoped route. Only a bearer
+// token holding that scope may.
+func TestMiddlewareClientCertDoesNotBypassScopes(t *testing.T) {
+	store := NewStore(settings.NewSettingsService())
+	mw := Middleware(store, ScopeSettingsAdmin)
+	handlerCalled := false
+	next := func(c echo.Context) error {
+		handlerCalled = true
+		return c.String(http.StatusOK, "ok")
+	}
+
+	e := echo.New()
+	req := withPeerIdentityRequest(PeerIdentity{CommonName: "trusted-client"})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := mw(next)(c); err != nil {
+		t.Fatalf("middleware returned error: %v", err)
+	}
+	if handlerCalled {
+		t.Fatal("expected a verified client certificate alone not to satisfy settings:admin")
+	}
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a bearer token, got %d", rec.Code)
+	}
+}
+
+// TestMiddlewareClientCertWithScopedTokenSucceeds confirms the fix didn't
+// just lock mTLS clients out entirely: presenting a scoped bearer token
+// alongside a verified client certificate still succeeds.
+func TestMiddlewareClientCertWithScopedTokenSucceeds(t *testing.T) {
+	settingsService := settings.NewSettingsService()
+	store := NewStore(settingsService)
+	secret, _, err := store.Create("mtls-client", []Scope{ScopeSettingsAdmin})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	mw := Middleware(store, ScopeSettingsAdmin)
+	handlerCalled := false
+	next := func(c echo.Context) error {
+		handlerCalled = true
+		return c.String(http.StatusOK, "ok")
+	}
+
+	e := echo.New()
+	req := withPeerIdentityRequest(PeerIdentity{CommonName: "trusted-client"})
+	req.Header.Set("Authorization", "Bearer "+secret)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := mw(next)(c); err != nil {
+		t.Fatalf("middleware returned error: %v", err)
+	}
+	if !handlerCalled {
+		t.Fatalf("expected a scoped bearer token to be honored, got status %d", rec.Code)
+	}
+}
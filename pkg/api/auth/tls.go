@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+)
+
+type peerIdentityContextKey struct{}
+
+// PeerIdentity is the identity extracted from a verified client certificate's
+// subject CommonName and Subject Alternative Names, populated by
+// TLSIdentityMiddleware when the listener verifies client certificates.
+type PeerIdentity struct {
+	CommonName string
+	DNSNames   []string
+	EmailAddrs []string
+}
+
+// withPeerIdentity returns a context carrying identity for later retrieval
+// via PeerIdentityFromContext.
+func withPeerIdentity(ctx context.Context, identity PeerIdentity) context.Context {
+	return context.WithValue(ctx, peerIdentityContextKey{}, identity)
+}
+
+// PeerIdentityFromContext returns the identity extracted from the request's
+// verified client certificate, if any.
+func PeerIdentityFromContext(ctx context.Context) (PeerIdentity, bool) {
+	identity, ok := ctx.Value(peerIdentityContextKey{}).(PeerIdentity)
+	return identity, ok
+}
+
+// TLSIdentityMiddleware populates the request context with the verified
+// client certificate's CN/SANs, so Middleware can record which client made a
+// request; Middleware still requires a bearer token with the route's scope
+// whether or not a client cert was presented. It's a no-op unless the
+// connection actually presented a peer certificate, which only happens when
+// the listener's tls.Config.ClientAuth is VerifyClientCertIfGiven or
+// RequireAndVerifyClientCert and the client presented one; Go's TLS stack has
+// already verified it against the configured client CA pool by the time the
+// handler runs.
+func TLSIdentityMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if state := c.Request().TLS; state != nil && len(state.PeerCertificates) > 0 {
+				cert := state.PeerCertificates[0]
+				identity := PeerIdentity{
+					CommonName: cert.Subject.CommonName,
+					DNSNames:   cert.DNSNames,
+					EmailAddrs: cert.EmailAddresses,
+				}
+				c.SetRequest(c.Request().WithContext(withPeerIdentity(c.Request().Context(), identity)))
+			}
+			return next(c)
+		}
+	}
+}
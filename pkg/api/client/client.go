@@ -0,0 +1,47 @@
+// Package client is a thin Go wrapper around the BouncerService gRPC
+// contract in pkg/api/proto, for programmatic callers (CLIs, other daemons)
+// that want generated-style stubs instead of hand-rolled calls against the
+// REST API in pkg/api.
+package client
+
+import (
+	"context"
+
+	apiproto "github.com/catkins/mcp-bouncer/pkg/api/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client wraps a BouncerService connection dialed against a running
+// mcp-bouncer instance's gRPC listen address.
+type Client struct {
+	conn *grpc.ClientConn
+	apiproto.BouncerServiceClient
+}
+
+// Dial connects to a BouncerService server at addr (host:port) over plain
+// TCP. Callers that need TLS should build their own *grpc.ClientConn with
+// the appropriate transport credentials and pass it to New instead.
+func Dial(ctx context.Context, addr string) (*Client, error) {
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(apiproto.CodecName())),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return New(conn), nil
+}
+
+// New wraps an already-dialed *grpc.ClientConn. conn must have been dialed
+// with grpc.WithDefaultCallOptions(grpc.CallContentSubtype(apiproto.CodecName()))
+// so requests and responses use the codec BouncerService's server expects;
+// see pkg/api/proto/codec.go.
+func New(conn *grpc.ClientConn) *Client {
+	return &Client{conn: conn, BouncerServiceClient: apiproto.NewBouncerServiceClient(conn)}
+}
+
+// Close tears down the underlying connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	"github.com/catkins/mcp-bouncer/pkg/api/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// grpcMethodScopes maps each BouncerService method's full name (as seen in
+// grpc.UnaryServerInfo.FullMethod / grpc.StreamServerInfo.FullMethod) to the
+// scope its REST equivalent in server.go requires, so the gRPC surface can't
+// be used to route around the auth hardening the REST routes already have.
+var grpcMethodScopes = map[string][]auth.Scope{
+	"/bouncer.v1.BouncerService/ListMCPServers":    {auth.ScopeMCPRead},
+	"/bouncer.v1.BouncerService/AddMCPServer":      {auth.ScopeMCPWrite},
+	"/bouncer.v1.BouncerService/UpdateMCPServer":   {auth.ScopeMCPWrite},
+	"/bouncer.v1.BouncerService/RemoveMCPServer":   {auth.ScopeMCPWrite},
+	"/bouncer.v1.BouncerService/RestartClient":     {auth.ScopeMCPWrite},
+	"/bouncer.v1.BouncerService/AuthorizeClient":   {auth.ScopeMCPWrite},
+	"/bouncer.v1.BouncerService/GetClientStatus":   {auth.ScopeMCPRead},
+	"/bouncer.v1.BouncerService/GetSettings":       {auth.ScopeSettingsRead},
+	"/bouncer.v1.BouncerService/WatchClientStatus": {auth.ScopeMCPRead},
+}
+
+// authenticateGRPC enforces the scopes grpcMethodScopes requires for
+// fullMethod against ctx, mirroring auth.Middleware's REST rules: a caller
+// connecting from a loopback address is let through unauthenticated, same as
+// the bundled desktop UI talking to the REST API over 127.0.0.1; everyone
+// else must present a "authorization: Bearer <token>" metadata entry holding
+// every required scope. A verified client certificate (mTLS) only
+// authenticates the transport - same as PeerIdentityFromContext on the REST
+// side, it doesn't by itself satisfy a scope. fullMethod must be listed in
+// grpcMethodScopes; an unrecognized method is rejected outright rather than
+// treated as requiring no scopes, so adding a new RPC without a matching
+// entry here fails closed instead of silently granting it to any
+// authenticated caller.
+func authenticateGRPC(ctx context.Context, store *auth.Store, fullMethod string) error {
+	required, ok := grpcMethodScopes[fullMethod]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "%s has no configured scope requirement", fullMethod)
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && auth.IsLoopbackAddr(p.Addr.String()) {
+		return nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	secret, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || secret == "" {
+		return status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	if _, ok := store.Authenticate(secret, required...); !ok {
+		return status.Error(codes.PermissionDenied, "token missing required scope")
+	}
+	return nil
+}
+
+// grpcAuthUnaryInterceptor enforces authenticateGRPC before every unary
+// BouncerService call.
+func grpcAuthUnaryInterceptor(store *auth.Store) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authenticateGRPC(ctx, store, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// grpcAuthStreamInterceptor enforces authenticateGRPC before every streaming
+// BouncerService call (WatchClientStatus).
+func grpcAuthStreamInterceptor(store *auth.Store) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authenticateGRPC(ss.Context(), store, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/catkins/mcp-bouncer/pkg/api/auth"
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func peerContext(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip)}})
+}
+
+func TestAuthenticateGRPCLoopbackBypass(t *testing.T) {
+	store := auth.NewStore(settings.NewSettingsService())
+	err := authenticateGRPC(peerContext("127.0.0.1"), store, "/bouncer.v1.BouncerService/GetSettings")
+	if err != nil {
+		t.Fatalf("expected loopback caller to bypass auth, got %v", err)
+	}
+}
+
+func TestAuthenticateGRPCRejectsMissingToken(t *testing.T) {
+	store := auth.NewStore(settings.NewSettingsService())
+	err := authenticateGRPC(peerContext("203.0.113.1"), store, "/bouncer.v1.BouncerService/GetSettings")
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without a bearer token, got %v", err)
+	}
+}
+
+func TestAuthenticateGRPCRejectsWrongScope(t *testing.T) {
+	settingsService := settings.NewSettingsService()
+	store := auth.NewStore(settingsService)
+	secret, _, err := store.Create("reader", []auth.Scope{auth.ScopeMCPRead})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	ctx := metadata.NewIncomingContext(peerContext("203.0.113.1"), metadata.Pairs("authorization", "Bearer "+secret))
+	err = authenticateGRPC(ctx, store, "/bouncer.v1.BouncerService/AddMCPServer")
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied for a read-only token calling AddMCPServer, got %v", err)
+	}
+}
+
+func TestAuthenticateGRPCRejectsUnknownMethod(t *testing.T) {
+	settingsService := settings.NewSettingsService()
+	store := auth.NewStore(settingsService)
+	secret, _, err := store.Create("writer", []auth.Scope{auth.ScopeMCPWrite, auth.ScopeSettingsAdmin})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	ctx := metadata.NewIncomingContext(peerContext("203.0.113.1"), metadata.Pairs("authorization", "Bearer "+secret))
+	err = authenticateGRPC(ctx, store, "/bouncer.v1.BouncerService/SomeFutureMethod")
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected an unlisted method to fail closed with PermissionDenied, got %v", err)
+	}
+}
+
+func TestAuthenticateGRPCAcceptsScopedToken(t *testing.T) {
+	settingsService := settings.NewSettingsService()
+	store := auth.NewStore(settingsService)
+	secret, _, err := store.Create("writer", []auth.Scope{auth.ScopeMCPWrite})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	ctx := metadata.NewIncomingContext(peerContext("203.0.113.1"), metadata.Pairs("authorization", "Bearer "+secret))
+	if err := authenticateGRPC(ctx, store, "/bouncer.v1.BouncerService/AddMCPServer"); err != nil {
+		t.Fatalf("expected a write-scoped token to be accepted, got %v", err)
+	}
+}
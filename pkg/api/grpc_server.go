@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+
+	"github.com/catkins/mcp-bouncer/pkg/api/auth"
+	apiproto "github.com/catkins/mcp-bouncer/pkg/api/proto"
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp"
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// bouncerServer implements apiproto.BouncerServiceServer on top of the same
+// mcpService/settingsService the REST handlers in server.go use, so the two
+// transports stay in lock-step without a separate service layer.
+type bouncerServer struct {
+	apiproto.UnimplementedBouncerServiceServer
+
+	mcpService      *mcp.MCPService
+	settingsService *settings.SettingsService
+}
+
+func toProtoMCPServerConfig(config settings.MCPServerConfig) *apiproto.MCPServerConfig {
+	return &apiproto.MCPServerConfig{
+		Name:        config.Name,
+		Description: config.Description,
+		Transport:   string(config.Transport),
+		Command:     config.Command,
+		Args:        config.Args,
+		Env:         config.Env,
+		Endpoint:    config.Endpoint,
+		Headers:     config.Headers,
+		Enabled:     config.Enabled,
+	}
+}
+
+func fromProtoMCPServerConfig(config *apiproto.MCPServerConfig) settings.MCPServerConfig {
+	if config == nil {
+		return settings.MCPServerConfig{}
+	}
+	return settings.MCPServerConfig{
+		Name:        config.Name,
+		Description: config.Description,
+		Transport:   settings.TransportType(config.Transport),
+		Command:     config.Command,
+		Args:        config.Args,
+		Env:         config.Env,
+		Endpoint:    config.Endpoint,
+		Headers:     config.Headers,
+		Enabled:     config.Enabled,
+	}
+}
+
+func toProtoClientStatus(name string, status mcp.ClientStatus) *apiproto.ClientStatus {
+	return &apiproto.ClientStatus{
+		Name:                  name,
+		Connected:             status.Connected,
+		LastError:             status.LastError,
+		AuthorizationRequired: status.AuthorizationRequired,
+		OAuthAuthenticated:    status.OAuthAuthenticated,
+	}
+}
+
+func (b *bouncerServer) ListMCPServers(ctx context.Context, in *apiproto.ListMCPServersRequest) (*apiproto.ListMCPServersResponse, error) {
+	servers, err := b.mcpService.List()
+	if err != nil {
+		return nil, err
+	}
+	resp := &apiproto.ListMCPServersResponse{Servers: make([]*apiproto.MCPServerConfig, len(servers))}
+	for i, server := range servers {
+		resp.Servers[i] = toProtoMCPServerConfig(server)
+	}
+	return resp, nil
+}
+
+func (b *bouncerServer) AddMCPServer(ctx context.Context, in *apiproto.AddMCPServerRequest) (*apiproto.AddMCPServerResponse, error) {
+	if err := b.mcpService.AddMCPServer(fromProtoMCPServerConfig(in.Config)); err != nil {
+		return nil, err
+	}
+	return &apiproto.AddMCPServerResponse{}, nil
+}
+
+func (b *bouncerServer) UpdateMCPServer(ctx context.Context, in *apiproto.UpdateMCPServerRequest) (*apiproto.UpdateMCPServerResponse, error) {
+	if err := b.mcpService.UpdateMCPServer(in.Name, fromProtoMCPServerConfig(in.Config)); err != nil {
+		return nil, err
+	}
+	return &apiproto.UpdateMCPServerResponse{}, nil
+}
+
+func (b *bouncerServer) RemoveMCPServer(ctx context.Context, in *apiproto.RemoveMCPServerRequest) (*apiproto.RemoveMCPServerResponse, error) {
+	if err := b.mcpService.RemoveMCPServer(in.Name); err != nil {
+		return nil, err
+	}
+	return &apiproto.RemoveMCPServerResponse{}, nil
+}
+
+func (b *bouncerServer) RestartClient(ctx context.Context, in *apiproto.RestartClientRequest) (*apiproto.RestartClientResponse, error) {
+	if err := b.mcpService.RestartClient(in.Name); err != nil {
+		return nil, err
+	}
+	return &apiproto.RestartClientResponse{}, nil
+}
+
+func (b *bouncerServer) AuthorizeClient(ctx context.Context, in *apiproto.AuthorizeClientRequest) (*apiproto.AuthorizeClientResponse, error) {
+	if err := b.mcpService.AuthorizeClient(in.Name); err != nil {
+		return nil, err
+	}
+	return &apiproto.AuthorizeClientResponse{}, nil
+}
+
+func (b *bouncerServer) GetClientStatus(ctx context.Context, in *apiproto.GetClientStatusRequest) (*apiproto.GetClientStatusResponse, error) {
+	return b.clientStatusResponse(), nil
+}
+
+func (b *bouncerServer) clientStatusResponse() *apiproto.GetClientStatusResponse {
+	status := b.mcpService.GetClientStatus()
+	resp := &apiproto.GetClientStatusResponse{Clients: make([]*apiproto.ClientStatus, 0, len(status))}
+	for name, s := range status {
+		resp.Clients = append(resp.Clients, toProtoClientStatus(name, s))
+	}
+	return resp
+}
+
+func (b *bouncerServer) GetSettings(ctx context.Context, in *apiproto.GetSettingsRequest) (*apiproto.GetSettingsResponse, error) {
+	payload, err := json.Marshal(b.settingsService.GetSettings())
+	if err != nil {
+		return nil, err
+	}
+	return &apiproto.GetSettingsResponse{SettingsJSON: payload}, nil
+}
+
+// WatchClientStatus pushes the full client status snapshot to stream every
+// time the mcp service reports a client status change, until ctx is
+// cancelled. REST has no good way to express this as a stream - it's the one
+// RPC with no Echo-handler equivalent in server.go.
+func (b *bouncerServer) WatchClientStatus(in *apiproto.WatchClientStatusRequest, stream apiproto.BouncerService_WatchClientStatusServer) error {
+	events, unsubscribe := b.mcpService.SubscribeEvents()
+	defer unsubscribe()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if event.Name != mcp.EventClientStatusChanged {
+				continue
+			}
+			if err := stream.Send(b.clientStatusResponse()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// grpcServer wraps the *grpc.Server serving BouncerService, tracked
+// alongside APIServer's REST listener so ListenAddr-style callers can learn
+// where it ended up bound when the configured address uses an ephemeral
+// port.
+type grpcServer struct {
+	server     *grpc.Server
+	listenAddr string
+}
+
+// startGRPCServer binds addr, registers a BouncerServiceServer backed by
+// mcpService and settingsService, and starts serving in a background
+// goroutine. Every call is authenticated the same way server.go's REST
+// routes are - grpcAuthUnaryInterceptor/grpcAuthStreamInterceptor check
+// authStore for a bearer token holding grpcMethodScopes' required scope,
+// exempting only loopback callers - and tlsConfig (nil unless
+// settings.GetTLSConfig() is enabled, same source the REST listener uses)
+// is wired in as transport credentials so gRPC gets the same TLS/mTLS
+// posture as REST rather than always serving plaintext. Returns the bound
+// grpcServer so its effective address is available immediately, mirroring
+// APIServer.Start's handling of the REST listener.
+func startGRPCServer(addr string, mcpService *mcp.MCPService, settingsService *settings.SettingsService, authStore *auth.Store, tlsConfig *tls.Config) (*grpcServer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(grpcAuthUnaryInterceptor(authStore)),
+		grpc.ChainStreamInterceptor(grpcAuthStreamInterceptor(authStore)),
+	}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	server := grpc.NewServer(opts...)
+	apiproto.RegisterBouncerServiceServer(server, &bouncerServer{
+		mcpService:      mcpService,
+		settingsService: settingsService,
+	})
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	return &grpcServer{server: server, listenAddr: listener.Addr().String()}, nil
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	apiproto "github.com/catkins/mcp-bouncer/pkg/api/proto"
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp"
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newSharedServices builds an mcp.MCPService/settings.SettingsService pair
+// seeded with one "example" server, for tests that drive both the REST and
+// gRPC surfaces against the same backing services (see rest_server_test.go).
+func newSharedServices(t *testing.T) (*settings.SettingsService, *mcp.MCPService) {
+	t.Helper()
+
+	settingsService := settings.NewSettingsService()
+	if err := settingsService.AddMCPServer(settings.MCPServerConfig{
+		Name:      "example",
+		Transport: settings.TransportStdio,
+		Command:   "true",
+	}); err != nil {
+		t.Fatalf("AddMCPServer: %v", err)
+	}
+	return settingsService, mcp.NewMCPService(settingsService)
+}
+
+// dialBouncerService starts a BouncerService gRPC server backed by fresh
+// mcp/settings services over an in-memory bufconn listener and returns a
+// connected client, so tests exercise the real grpc.Server/ServiceDesc
+// wiring in grpc_server.go and bouncer_grpc.go without binding a real port.
+func dialBouncerService(t *testing.T) apiproto.BouncerServiceClient {
+	t.Helper()
+
+	settingsService, mcpService := newSharedServices(t)
+	return dialBouncerServiceWithServices(t, mcpService, settingsService)
+}
+
+// dialBouncerServiceWithServices is dialBouncerService but against
+// caller-provided services, so a test can exercise the same mcpService and
+// settingsService through both the gRPC and REST surfaces at once.
+func dialBouncerServiceWithServices(t *testing.T, mcpService *mcp.MCPService, settingsService *settings.SettingsService) apiproto.BouncerServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	apiproto.RegisterBouncerServiceServer(server, &bouncerServer{
+		mcpService:      mcpService,
+		settingsService: settingsService,
+	})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(apiproto.CodecName())),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return apiproto.NewBouncerServiceClient(conn)
+}
+
+func TestBouncerServiceListMCPServers(t *testing.T) {
+	client := dialBouncerService(t)
+
+	resp, err := client.ListMCPServers(context.Background(), &apiproto.ListMCPServersRequest{})
+	if err != nil {
+		t.Fatalf("ListMCPServers: %v", err)
+	}
+	if len(resp.Servers) != 1 || resp.Servers[0].Name != "example" {
+		t.Fatalf("unexpected servers: %+v", resp.Servers)
+	}
+}
+
+func TestBouncerServiceGetClientStatus(t *testing.T) {
+	client := dialBouncerService(t)
+
+	resp, err := client.GetClientStatus(context.Background(), &apiproto.GetClientStatusRequest{})
+	if err != nil {
+		t.Fatalf("GetClientStatus: %v", err)
+	}
+	if len(resp.Clients) != 0 {
+		t.Fatalf("expected no running clients, got %+v", resp.Clients)
+	}
+}
+
+func TestBouncerServiceAddAndRemoveMCPServer(t *testing.T) {
+	client := dialBouncerService(t)
+	ctx := context.Background()
+
+	_, err := client.AddMCPServer(ctx, &apiproto.AddMCPServerRequest{
+		Config: &apiproto.MCPServerConfig{Name: "added", Transport: "stdio", Command: "true"},
+	})
+	if err != nil {
+		t.Fatalf("AddMCPServer: %v", err)
+	}
+
+	listResp, err := client.ListMCPServers(ctx, &apiproto.ListMCPServersRequest{})
+	if err != nil {
+		t.Fatalf("ListMCPServers: %v", err)
+	}
+	if len(listResp.Servers) != 2 {
+		t.Fatalf("expected 2 servers after add, got %d", len(listResp.Servers))
+	}
+
+	if _, err := client.RemoveMCPServer(ctx, &apiproto.RemoveMCPServerRequest{Name: "added"}); err != nil {
+		t.Fatalf("RemoveMCPServer: %v", err)
+	}
+
+	listResp, err = client.ListMCPServers(ctx, &apiproto.ListMCPServersRequest{})
+	if err != nil {
+		t.Fatalf("ListMCPServers: %v", err)
+	}
+	if len(listResp.Servers) != 1 {
+		t.Fatalf("expected 1 server after remove, got %d", len(listResp.Servers))
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Prometheus collectors scraped from /metrics, giving operators request-rate
+// and latency breakdowns for the REST API alongside a live count of MCP
+// clients by status.
+var (
+	metricsHTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_bouncer_http_requests_total",
+		Help: "Total REST API requests, by method, route, and status code.",
+	}, []string{"method", "route", "code"})
+
+	metricsHTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mcp_bouncer_http_request_duration_seconds",
+		Help:    "Duration of REST API requests, by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	metricsClients = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mcp_bouncer_clients",
+		Help: "Number of configured MCP clients by status.",
+	}, []string{"status"})
+)
+
+func init() {
+	prometheus.MustRegister(metricsHTTPRequests, metricsHTTPRequestDuration, metricsClients)
+}
+
+// metricsMiddleware records mcp_bouncer_http_requests_total and
+// mcp_bouncer_http_request_duration_seconds for every request, keyed by the
+// matched route pattern rather than the raw path so routes like
+// /api/mcp/servers/:name don't blow up cardinality.
+func metricsMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			duration := time.Since(start)
+
+			method := c.Request().Method
+			route := c.Path()
+			code := c.Response().Status
+			if he, ok := err.(*echo.HTTPError); ok {
+				code = he.Code
+			}
+
+			metricsHTTPRequests.WithLabelValues(method, route, strconv.Itoa(code)).Inc()
+			metricsHTTPRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+			return err
+		}
+	}
+}
+
+// refreshClientMetrics recomputes mcp_bouncer_clients from the current
+// client status snapshot. It's called on every scrape rather than on a
+// timer, so the gauges are never more stale than the last /metrics request.
+func (s *APIServer) refreshClientMetrics() {
+	var connected, erroring, unauthorized float64
+	for _, status := range s.mcpService.GetClientStatus() {
+		switch {
+		case status.AuthorizationRequired:
+			unauthorized++
+		case status.LastError != "":
+			erroring++
+		case status.Connected:
+			connected++
+		}
+	}
+	metricsClients.WithLabelValues("connected").Set(connected)
+	metricsClients.WithLabelValues("error").Set(erroring)
+	metricsClients.WithLabelValues("unauthorized").Set(unauthorized)
+}
+
+// metricsEndpoint wraps promhttp.Handler to refresh the client-status gauges
+// from the live snapshot immediately before each scrape.
+func (s *APIServer) metricsEndpoint() echo.HandlerFunc {
+	handler := promhttp.Handler()
+	return func(c echo.Context) error {
+		s.refreshClientMetrics()
+		handler.ServeHTTP(c.Response(), c.Request())
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/catkins/mcp-bouncer/pkg/api/auth"
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+	"github.com/labstack/echo/v4"
+)
+
+// loggingMiddleware logs method, path, status, latency, and remote address
+// for every request, plus the authenticated API token's id once auth.Middleware
+// has set one on the context, so production issues can be traced back to the
+// caller that triggered them.
+func loggingMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			attrs := []any{
+				"method", c.Request().Method,
+				"path", c.Path(),
+				"status", c.Response().Status,
+				"duration_ms", time.Since(start).Milliseconds(),
+				"remote_addr", c.RealIP(),
+			}
+			if token, ok := c.Get("api_token").(settings.APIToken); ok {
+				attrs = append(attrs, "token_id", token.ID)
+			}
+			if identity, ok := auth.PeerIdentityFromContext(c.Request().Context()); ok {
+				attrs = append(attrs, "peer_cn", identity.CommonName)
+			}
+
+			if err != nil {
+				slog.Error("HTTP request", append(attrs, "error", err)...)
+			} else {
+				slog.Info("HTTP request", attrs...)
+			}
+			return err
+		}
+	}
+}
+
+// recoverMiddleware converts a panicking handler into a structured 500
+// response and a logged stack trace, instead of taking down the whole
+// process the way an unrecovered panic in an HTTP handler goroutine would.
+func recoverMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					panicErr, ok := r.(error)
+					if !ok {
+						panicErr = fmt.Errorf("%v", r)
+					}
+					slog.Error("panic handling HTTP request",
+						"method", c.Request().Method,
+						"path", c.Path(),
+						"error", panicErr,
+						"stack", string(debug.Stack()))
+					err = c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+				}
+			}()
+			return next(c)
+		}
+	}
+}
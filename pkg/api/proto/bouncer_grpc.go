@@ -0,0 +1,380 @@
+package proto
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceName is the fully-qualified gRPC service name BouncerService
+// registers under, matching the `service BouncerService` declaration in
+// bouncer.proto's `bouncer.v1` package.
+const ServiceName = "bouncer.v1.BouncerService"
+
+// BouncerServiceClient is the client API for BouncerService, mirroring the
+// RPCs declared in bouncer.proto.
+type BouncerServiceClient interface {
+	ListMCPServers(ctx context.Context, in *ListMCPServersRequest, opts ...grpc.CallOption) (*ListMCPServersResponse, error)
+	AddMCPServer(ctx context.Context, in *AddMCPServerRequest, opts ...grpc.CallOption) (*AddMCPServerResponse, error)
+	UpdateMCPServer(ctx context.Context, in *UpdateMCPServerRequest, opts ...grpc.CallOption) (*UpdateMCPServerResponse, error)
+	RemoveMCPServer(ctx context.Context, in *RemoveMCPServerRequest, opts ...grpc.CallOption) (*RemoveMCPServerResponse, error)
+	RestartClient(ctx context.Context, in *RestartClientRequest, opts ...grpc.CallOption) (*RestartClientResponse, error)
+	AuthorizeClient(ctx context.Context, in *AuthorizeClientRequest, opts ...grpc.CallOption) (*AuthorizeClientResponse, error)
+	GetClientStatus(ctx context.Context, in *GetClientStatusRequest, opts ...grpc.CallOption) (*GetClientStatusResponse, error)
+	GetSettings(ctx context.Context, in *GetSettingsRequest, opts ...grpc.CallOption) (*GetSettingsResponse, error)
+	WatchClientStatus(ctx context.Context, in *WatchClientStatusRequest, opts ...grpc.CallOption) (BouncerService_WatchClientStatusClient, error)
+}
+
+type bouncerServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewBouncerServiceClient wraps cc, a connection dialed with
+// grpc.WithDefaultCallOptions(grpc.CallContentSubtype(proto.CodecName()))
+// so calls use bouncerCodec instead of the default protobuf codec.
+func NewBouncerServiceClient(cc *grpc.ClientConn) BouncerServiceClient {
+	return &bouncerServiceClient{cc: cc}
+}
+
+// CodecName returns the content-subtype callers must select via
+// grpc.CallContentSubtype when dialing, so requests and responses are
+// encoded with bouncerCodec rather than grpc-go's default protobuf codec.
+func CodecName() string {
+	return codecName
+}
+
+func (c *bouncerServiceClient) ListMCPServers(ctx context.Context, in *ListMCPServersRequest, opts ...grpc.CallOption) (*ListMCPServersResponse, error) {
+	out := new(ListMCPServersResponse)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/ListMCPServers", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bouncerServiceClient) AddMCPServer(ctx context.Context, in *AddMCPServerRequest, opts ...grpc.CallOption) (*AddMCPServerResponse, error) {
+	out := new(AddMCPServerResponse)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/AddMCPServer", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bouncerServiceClient) UpdateMCPServer(ctx context.Context, in *UpdateMCPServerRequest, opts ...grpc.CallOption) (*UpdateMCPServerResponse, error) {
+	out := new(UpdateMCPServerResponse)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/UpdateMCPServer", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bouncerServiceClient) RemoveMCPServer(ctx context.Context, in *RemoveMCPServerRequest, opts ...grpc.CallOption) (*RemoveMCPServerResponse, error) {
+	out := new(RemoveMCPServerResponse)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/RemoveMCPServer", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bouncerServiceClient) RestartClient(ctx context.Context, in *RestartClientRequest, opts ...grpc.CallOption) (*RestartClientResponse, error) {
+	out := new(RestartClientResponse)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/RestartClient", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bouncerServiceClient) AuthorizeClient(ctx context.Context, in *AuthorizeClientRequest, opts ...grpc.CallOption) (*AuthorizeClientResponse, error) {
+	out := new(AuthorizeClientResponse)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/AuthorizeClient", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bouncerServiceClient) GetClientStatus(ctx context.Context, in *GetClientStatusRequest, opts ...grpc.CallOption) (*GetClientStatusResponse, error) {
+	out := new(GetClientStatusResponse)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/GetClientStatus", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bouncerServiceClient) GetSettings(ctx context.Context, in *GetSettingsRequest, opts ...grpc.CallOption) (*GetSettingsResponse, error) {
+	out := new(GetSettingsResponse)
+	if err := c.cc.Invoke(ctx, "/"+ServiceName+"/GetSettings", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bouncerServiceClient) WatchClientStatus(ctx context.Context, in *WatchClientStatusRequest, opts ...grpc.CallOption) (BouncerService_WatchClientStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BouncerService_serviceDesc.Streams[0], "/"+ServiceName+"/WatchClientStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bouncerServiceWatchClientStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// BouncerService_WatchClientStatusClient is the stream type WatchClientStatus
+// returns to the caller, yielding one GetClientStatusResponse per client
+// status change until ctx is cancelled or the server closes the stream.
+type BouncerService_WatchClientStatusClient interface {
+	Recv() (*GetClientStatusResponse, error)
+	grpc.ClientStream
+}
+
+type bouncerServiceWatchClientStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *bouncerServiceWatchClientStatusClient) Recv() (*GetClientStatusResponse, error) {
+	m := new(GetClientStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BouncerServiceServer is the server API for BouncerService. Implementations
+// are registered with a *grpc.Server via RegisterBouncerServiceServer.
+type BouncerServiceServer interface {
+	ListMCPServers(context.Context, *ListMCPServersRequest) (*ListMCPServersResponse, error)
+	AddMCPServer(context.Context, *AddMCPServerRequest) (*AddMCPServerResponse, error)
+	UpdateMCPServer(context.Context, *UpdateMCPServerRequest) (*UpdateMCPServerResponse, error)
+	RemoveMCPServer(context.Context, *RemoveMCPServerRequest) (*RemoveMCPServerResponse, error)
+	RestartClient(context.Context, *RestartClientRequest) (*RestartClientResponse, error)
+	AuthorizeClient(context.Context, *AuthorizeClientRequest) (*AuthorizeClientResponse, error)
+	GetClientStatus(context.Context, *GetClientStatusRequest) (*GetClientStatusResponse, error)
+	GetSettings(context.Context, *GetSettingsRequest) (*GetSettingsResponse, error)
+	WatchClientStatus(*WatchClientStatusRequest, BouncerService_WatchClientStatusServer) error
+}
+
+// BouncerService_WatchClientStatusServer is the server-side handle for a
+// WatchClientStatus stream, used to push a GetClientStatusResponse to the
+// caller every time client status changes.
+type BouncerService_WatchClientStatusServer interface {
+	Send(*GetClientStatusResponse) error
+	grpc.ServerStream
+}
+
+type bouncerServiceWatchClientStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *bouncerServiceWatchClientStatusServer) Send(m *GetClientStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// RegisterBouncerServiceServer registers srv as the BouncerService
+// implementation s will serve.
+func RegisterBouncerServiceServer(s grpc.ServiceRegistrar, srv BouncerServiceServer) {
+	s.RegisterService(&_BouncerService_serviceDesc, srv)
+}
+
+// UnimplementedBouncerServiceServer can be embedded in a BouncerServiceServer
+// implementation to satisfy the interface with unimplemented RPCs returning
+// codes.Unimplemented, so adding a new RPC to the service doesn't break
+// existing implementations that embed it.
+type UnimplementedBouncerServiceServer struct{}
+
+func (UnimplementedBouncerServiceServer) ListMCPServers(context.Context, *ListMCPServersRequest) (*ListMCPServersResponse, error) {
+	return nil, errUnimplemented("ListMCPServers")
+}
+
+func (UnimplementedBouncerServiceServer) AddMCPServer(context.Context, *AddMCPServerRequest) (*AddMCPServerResponse, error) {
+	return nil, errUnimplemented("AddMCPServer")
+}
+
+func (UnimplementedBouncerServiceServer) UpdateMCPServer(context.Context, *UpdateMCPServerRequest) (*UpdateMCPServerResponse, error) {
+	return nil, errUnimplemented("UpdateMCPServer")
+}
+
+func (UnimplementedBouncerServiceServer) RemoveMCPServer(context.Context, *RemoveMCPServerRequest) (*RemoveMCPServerResponse, error) {
+	return nil, errUnimplemented("RemoveMCPServer")
+}
+
+func (UnimplementedBouncerServiceServer) RestartClient(context.Context, *RestartClientRequest) (*RestartClientResponse, error) {
+	return nil, errUnimplemented("RestartClient")
+}
+
+func (UnimplementedBouncerServiceServer) AuthorizeClient(context.Context, *AuthorizeClientRequest) (*AuthorizeClientResponse, error) {
+	return nil, errUnimplemented("AuthorizeClient")
+}
+
+func (UnimplementedBouncerServiceServer) GetClientStatus(context.Context, *GetClientStatusRequest) (*GetClientStatusResponse, error) {
+	return nil, errUnimplemented("GetClientStatus")
+}
+
+func (UnimplementedBouncerServiceServer) GetSettings(context.Context, *GetSettingsRequest) (*GetSettingsResponse, error) {
+	return nil, errUnimplemented("GetSettings")
+}
+
+func (UnimplementedBouncerServiceServer) WatchClientStatus(*WatchClientStatusRequest, BouncerService_WatchClientStatusServer) error {
+	return errUnimplemented("WatchClientStatus")
+}
+
+func errUnimplemented(method string) error {
+	return status.Errorf(codes.Unimplemented, "method %s not implemented", method)
+}
+
+func _BouncerService_ListMCPServers_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(ListMCPServersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BouncerServiceServer).ListMCPServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/ListMCPServers"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(BouncerServiceServer).ListMCPServers(ctx, req.(*ListMCPServersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BouncerService_AddMCPServer_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(AddMCPServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BouncerServiceServer).AddMCPServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/AddMCPServer"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(BouncerServiceServer).AddMCPServer(ctx, req.(*AddMCPServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BouncerService_UpdateMCPServer_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(UpdateMCPServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BouncerServiceServer).UpdateMCPServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/UpdateMCPServer"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(BouncerServiceServer).UpdateMCPServer(ctx, req.(*UpdateMCPServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BouncerService_RemoveMCPServer_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(RemoveMCPServerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BouncerServiceServer).RemoveMCPServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/RemoveMCPServer"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(BouncerServiceServer).RemoveMCPServer(ctx, req.(*RemoveMCPServerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BouncerService_RestartClient_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(RestartClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BouncerServiceServer).RestartClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/RestartClient"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(BouncerServiceServer).RestartClient(ctx, req.(*RestartClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BouncerService_AuthorizeClient_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(AuthorizeClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BouncerServiceServer).AuthorizeClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/AuthorizeClient"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(BouncerServiceServer).AuthorizeClient(ctx, req.(*AuthorizeClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BouncerService_GetClientStatus_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(GetClientStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BouncerServiceServer).GetClientStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/GetClientStatus"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(BouncerServiceServer).GetClientStatus(ctx, req.(*GetClientStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BouncerService_GetSettings_Handler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(GetSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BouncerServiceServer).GetSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/GetSettings"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(BouncerServiceServer).GetSettings(ctx, req.(*GetSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BouncerService_WatchClientStatus_Handler(srv any, stream grpc.ServerStream) error {
+	m := new(WatchClientStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BouncerServiceServer).WatchClientStatus(m, &bouncerServiceWatchClientStatusServer{stream})
+}
+
+// _BouncerService_serviceDesc is the grpc.ServiceDesc RegisterBouncerServiceServer
+// registers, mirroring protoc-gen-go-grpc's generated descriptor.
+var _BouncerService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*BouncerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ListMCPServers", Handler: _BouncerService_ListMCPServers_Handler},
+		{MethodName: "AddMCPServer", Handler: _BouncerService_AddMCPServer_Handler},
+		{MethodName: "UpdateMCPServer", Handler: _BouncerService_UpdateMCPServer_Handler},
+		{MethodName: "RemoveMCPServer", Handler: _BouncerService_RemoveMCPServer_Handler},
+		{MethodName: "RestartClient", Handler: _BouncerService_RestartClient_Handler},
+		{MethodName: "AuthorizeClient", Handler: _BouncerService_AuthorizeClient_Handler},
+		{MethodName: "GetClientStatus", Handler: _BouncerService_GetClientStatus_Handler},
+		{MethodName: "GetSettings", Handler: _BouncerService_GetSettings_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchClientStatus",
+			Handler:       _BouncerService_WatchClientStatus_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bouncer.proto",
+}
@@ -0,0 +1,39 @@
+package proto
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName is the gRPC content-subtype bouncerCodec registers under.
+// Clients select it per-call with grpc.CallContentSubtype(codecName); the
+// server resolves it automatically once bouncerCodec is registered, since
+// encoding.RegisterCodec makes it available to any *grpc.Server in the
+// process.
+const codecName = "bouncerjson"
+
+// bouncerCodec is a minimal encoding.Codec that marshals RPC messages as
+// JSON instead of protobuf wire format. The real protobuf codec requires
+// generated stubs that satisfy proto.Message, which protoc-gen-go can't
+// produce here without protoc on PATH (see bouncer.go); this keeps
+// BouncerService functional - real HTTP/2 framing, streaming, deadlines,
+// and metadata all still go through grpc-go as normal - until the generated
+// stubs can be swapped in.
+type bouncerCodec struct{}
+
+func (bouncerCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (bouncerCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (bouncerCodec) Name() string {
+	return codecName
+}
+
+func init() {
+	encoding.RegisterCodec(bouncerCodec{})
+}
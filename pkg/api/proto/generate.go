@@ -0,0 +1,14 @@
+// Package proto holds the gRPC contract for BouncerService: bouncer.proto is
+// the source of truth, and bouncer.go/bouncer_grpc.go are the Go types and
+// service registration it describes.
+//
+// Those two files are hand-maintained rather than generated: protoc and the
+// protoc-gen-go/protoc-gen-go-grpc plugins aren't reliably available in
+// every environment this repo builds in. Until they are, BouncerService
+// runs over codec.go's JSON codec instead of real protobuf wire encoding -
+// see its doc comment. Once protoc is available, regenerate with
+// `go generate ./...`, delete codec.go, and drop the CallContentSubtype
+// option from callers of NewBouncerServiceClient.
+package proto
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative bouncer.proto
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/catkins/mcp-bouncer/pkg/api/auth"
+	apiproto "github.com/catkins/mcp-bouncer/pkg/api/proto"
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp"
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+)
+
+// newRESTTestServer stands up an APIServer's Echo app over httptest, backed
+// by mcpService/settingsService, and returns it alongside a bearer token
+// scoped for every route so tests exercise the real middleware chain
+// (auth.Middleware, loggingMiddleware, recoverMiddleware) instead of calling
+// handlers directly.
+func newRESTTestServer(t *testing.T, mcpService *mcp.MCPService, settingsService *settings.SettingsService) (*httptest.Server, string) {
+	t.Helper()
+
+	apiServer := NewAPIServer(mcpService, settingsService)
+	secret, _, err := apiServer.authStore.Create("test", []auth.Scope{
+		auth.ScopeMCPRead, auth.ScopeMCPWrite,
+		auth.ScopeSettingsRead, auth.ScopeSettingsAdmin,
+	})
+	if err != nil {
+		t.Fatalf("authStore.Create: %v", err)
+	}
+
+	srv := httptest.NewServer(apiServer.echo)
+	t.Cleanup(srv.Close)
+	return srv, secret
+}
+
+// doREST issues a request against srv with the bearer token attached,
+// decoding a JSON response body into out when non-nil.
+func doREST(t *testing.T, srv *httptest.Server, token, method, path string, body, out any) *http.Response {
+	t.Helper()
+
+	var reqBody *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("marshal request body: %v", err)
+		}
+		reqBody = bytes.NewReader(data)
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequest(method, srv.URL+path, reqBody)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("decode response body: %v", err)
+		}
+	}
+	return resp
+}
+
+// TestRESTListMCPServers covers the same ground as
+// TestBouncerServiceListMCPServers against the REST surface instead of gRPC.
+func TestRESTListMCPServers(t *testing.T) {
+	settingsService, mcpService := newSharedServices(t)
+	srv, token := newRESTTestServer(t, mcpService, settingsService)
+
+	var servers []map[string]any
+	resp := doREST(t, srv, token, http.MethodGet, "/api/mcp/servers", nil, &servers)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /api/mcp/servers: status %d", resp.StatusCode)
+	}
+	if len(servers) != 1 || servers[0]["name"] != "example" {
+		t.Fatalf("unexpected servers: %+v", servers)
+	}
+}
+
+// TestRESTAndGRPCShareBackingServices drives the REST and gRPC surfaces
+// against the same mcpService/settingsService and checks a write made
+// through one transport is immediately visible through the other, per
+// chunk4-1's ask for integration tests exercising both transports against
+// the same in-process services.
+func TestRESTAndGRPCShareBackingServices(t *testing.T) {
+	settingsService, mcpService := newSharedServices(t)
+	grpcClient := dialBouncerServiceWithServices(t, mcpService, settingsService)
+	restSrv, restToken := newRESTTestServer(t, mcpService, settingsService)
+	ctx := context.Background()
+
+	var servers []map[string]any
+	resp := doREST(t, restSrv, restToken, http.MethodGet, "/api/mcp/servers", nil, &servers)
+	if resp.StatusCode != http.StatusOK || len(servers) != 1 {
+		t.Fatalf("expected 1 server over REST before the write, got status %d servers %+v", resp.StatusCode, servers)
+	}
+
+	newServer := map[string]any{"name": "added-via-rest", "transport": "stdio", "command": "true"}
+	resp = doREST(t, restSrv, restToken, http.MethodPost, "/api/mcp/servers", newServer, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST /api/mcp/servers: status %d", resp.StatusCode)
+	}
+
+	grpcResp, err := grpcClient.ListMCPServers(ctx, &apiproto.ListMCPServersRequest{})
+	if err != nil {
+		t.Fatalf("ListMCPServers: %v", err)
+	}
+	if len(grpcResp.Servers) != 2 {
+		t.Fatalf("expected the REST-added server to be visible over gRPC, got %+v", grpcResp.Servers)
+	}
+
+	if _, err := grpcClient.RemoveMCPServer(ctx, &apiproto.RemoveMCPServerRequest{Name: "added-via-rest"}); err != nil {
+		t.Fatalf("RemoveMCPServer: %v", err)
+	}
+
+	servers = nil
+	resp = doREST(t, restSrv, restToken, http.MethodGet, "/api/mcp/servers", nil, &servers)
+	if resp.StatusCode != http.StatusOK || len(servers) != 1 {
+		t.Fatalf("expected the gRPC removal to be visible over REST, got status %d servers %+v", resp.StatusCode, servers)
+	}
+}
@@ -1,18 +1,36 @@
 package api
 
 import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/catkins/mcp-bouncer/pkg/api/auth"
 	"github.com/catkins/mcp-bouncer/pkg/services/mcp"
 	"github.com/catkins/mcp-bouncer/pkg/services/settings"
 	"github.com/catkins/mcp-bouncer/pkg/services/settings/models"
 	"github.com/labstack/echo/v4"
 )
 
+// sseHeartbeatInterval is how often streamEvents writes a comment-only frame
+// to keep the connection alive through proxies that time out idle streams.
+const sseHeartbeatInterval = 15 * time.Second
+
 type APIServer struct {
 	mcpService      *mcp.MCPService
 	settingsService *settings.SettingsService
 	echo            *echo.Echo
+	authStore       *auth.Store
+
+	listenAddrMutex sync.RWMutex
+	listenAddr      string
+
+	grpcMutex sync.RWMutex
+	grpc      *grpcServer
 }
 
 func NewAPIServer(mcpService *mcp.MCPService, settingsService *settings.SettingsService) *APIServer {
@@ -21,31 +39,118 @@ func NewAPIServer(mcpService *mcp.MCPService, settingsService *settings.Settings
 		mcpService:      mcpService,
 		settingsService: settingsService,
 		echo:            e,
+		authStore:       auth.NewStore(settingsService),
+	}
+	if err := auth.EnsureBootstrapToken(s.authStore); err != nil {
+		e.Logger.Errorf("failed to create bootstrap API token: %s", err)
 	}
 	s.registerRoutes()
 	return s
 }
 
+// Start binds the configured API listen address (settings.GetAPIListenAddr,
+// supporting ":0" for an ephemeral port) and serves until the process exits
+// or the listener fails. When settings.GetTLSConfig() is enabled, the
+// listener serves TLS (and mTLS, per its ClientAuthMode) instead of plain
+// HTTP. ListenAddr reports the effective bound address once this has
+// completed binding. It also starts the BouncerService gRPC server on
+// settings.GetGRPCListenAddr in the background, sharing the same TLS config
+// and authStore as the REST listener so it can't be used to route around
+// REST's auth/TLS hardening; GRPCListenAddr reports its effective bound
+// address once that completes.
 func (s *APIServer) Start() {
-	s.echo.Logger.Fatal(s.echo.Start(":8080"))
+	tlsConfig, err := buildTLSConfig(s.settingsService.GetTLSConfig())
+	if err != nil {
+		s.echo.Logger.Fatal(err)
+		return
+	}
+
+	if grpcSrv, err := startGRPCServer(s.settingsService.GetGRPCListenAddr(), s.mcpService, s.settingsService, s.authStore, tlsConfig); err != nil {
+		s.echo.Logger.Errorf("failed to start BouncerService gRPC server: %s", err)
+	} else {
+		s.grpcMutex.Lock()
+		s.grpc = grpcSrv
+		s.grpcMutex.Unlock()
+	}
+
+	addr := s.settingsService.GetAPIListenAddr()
+
+	var listener net.Listener
+	if tlsConfig != nil {
+		listener, err = tls.Listen("tcp", addr, tlsConfig)
+	} else {
+		listener, err = net.Listen("tcp", addr)
+	}
+	if err != nil {
+		s.echo.Logger.Fatal(err)
+		return
+	}
+
+	s.listenAddrMutex.Lock()
+	s.listenAddr = listener.Addr().String()
+	s.listenAddrMutex.Unlock()
+
+	s.echo.Listener = listener
+	s.echo.Logger.Fatal(s.echo.Start(addr))
+}
+
+// ListenAddr returns the REST API's effective bind address, populated once
+// Start has bound its listener; empty beforehand.
+func (s *APIServer) ListenAddr() string {
+	s.listenAddrMutex.RLock()
+	defer s.listenAddrMutex.RUnlock()
+	return s.listenAddr
+}
+
+// GRPCListenAddr returns the BouncerService gRPC server's effective bind
+// address, populated once Start has bound its listener; empty beforehand.
+func (s *APIServer) GRPCListenAddr() string {
+	s.grpcMutex.RLock()
+	defer s.grpcMutex.RUnlock()
+	if s.grpc == nil {
+		return ""
+	}
+	return s.grpc.listenAddr
 }
 
 func (s *APIServer) registerRoutes() {
+	s.echo.Use(recoverMiddleware())
+	s.echo.Use(auth.TLSIdentityMiddleware())
+	s.echo.Use(loggingMiddleware())
+	s.echo.Use(metricsMiddleware())
+
+	s.echo.GET("/metrics", s.metricsEndpoint())
+
 	api := s.echo.Group("/api")
+
+	read := auth.Middleware(s.authStore, auth.ScopeMCPRead)
+	write := auth.Middleware(s.authStore, auth.ScopeMCPWrite)
+
+	api.GET("/listen-addr", s.getAPIListenAddr, read)
+
 	mcpGroup := api.Group("/mcp")
-	mcpGroup.GET("/servers", s.listMCPServers)
-	mcpGroup.POST("/servers", s.addMCPServer)
-	mcpGroup.PUT("/servers/:name", s.updateMCPServer)
-	mcpGroup.DELETE("/servers/:name", s.removeMCPServer)
-	mcpGroup.POST("/servers/:name/restart", s.restartClient)
-	mcpGroup.POST("/servers/:name/authorize", s.authorizeClient)
-	mcpGroup.GET("/listen-addr", s.listenAddr)
-	mcpGroup.GET("/is-active", s.isActive)
-	mcpGroup.GET("/client-status", s.getClientStatus)
+	mcpGroup.GET("/servers", s.listMCPServers, read)
+	mcpGroup.POST("/servers", s.addMCPServer, write)
+	mcpGroup.PUT("/servers/:name", s.updateMCPServer, write)
+	mcpGroup.DELETE("/servers/:name", s.removeMCPServer, write)
+	mcpGroup.POST("/servers/:name/restart", s.restartClient, write)
+	mcpGroup.POST("/servers/:name/authorize", s.authorizeClient, write)
+	mcpGroup.GET("/listen-addr", s.listenAddr, read)
+	mcpGroup.GET("/is-active", s.isActive, read)
+	mcpGroup.GET("/client-status", s.getClientStatus, read)
+	mcpGroup.GET("/events", s.streamEvents, read)
+
+	settingsRead := auth.Middleware(s.authStore, auth.ScopeSettingsRead)
+	settingsAdmin := auth.Middleware(s.authStore, auth.ScopeSettingsAdmin)
 
 	settingsGroup := api.Group("/settings")
-	settingsGroup.GET("", s.getSettings)
-	settingsGroup.POST("/open-config-directory", s.openConfigDirectory)
+	settingsGroup.GET("", s.getSettings, settingsRead)
+	settingsGroup.POST("/open-config-directory", s.openConfigDirectory, settingsAdmin)
+
+	tokensGroup := api.Group("/tokens")
+	tokensGroup.POST("", s.createAPIToken, settingsAdmin)
+	tokensGroup.GET("", s.listAPITokens, settingsAdmin)
+	tokensGroup.DELETE("/:id", s.revokeAPIToken, settingsAdmin)
 }
 
 func (s *APIServer) listMCPServers(c echo.Context) error {
@@ -103,6 +208,12 @@ func (s *APIServer) authorizeClient(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// getAPIListenAddr reports the REST API's own effective bind address, as
+// opposed to listenAddr below which reports the MCP service's.
+func (s *APIServer) getAPIListenAddr(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.ListenAddr())
+}
+
 func (s *APIServer) listenAddr(c echo.Context) error {
 	addr, err := s.mcpService.ListenAddr()
 	if err != nil {
@@ -124,6 +235,108 @@ func (s *APIServer) getClientStatus(c echo.Context) error {
 	return c.JSON(http.StatusOK, status)
 }
 
+// sseEventFrame is the JSON payload written as each SSE `data:` frame on
+// /api/mcp/events, translating the service's free-form internal events into
+// the handful of shapes event-stream consumers care about.
+type sseEventFrame struct {
+	Type                    string `json:"type"`
+	Name                    string `json:"name,omitempty"`
+	Status                  string `json:"status,omitempty"`
+	Error                   string `json:"error,omitempty"`
+	URL                     string `json:"url,omitempty"`
+	UserCode                string `json:"user_code,omitempty"`
+	VerificationURI         string `json:"verification_uri,omitempty"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+}
+
+// translateSSEEvent maps an internal mcp.Event onto an sseEventFrame, and
+// reports false for events that streamEvents has nothing useful to say about
+// (e.g. log lines), so the caller can skip writing a frame for them.
+func translateSSEEvent(event mcp.Event) (sseEventFrame, bool) {
+	data, _ := event.Data.(map[string]any)
+	str := func(key string) string {
+		v, _ := data[key].(string)
+		return v
+	}
+
+	switch event.Name {
+	case mcp.EventClientStatusChanged:
+		return sseEventFrame{Type: "client_status", Name: str("server_name"), Status: str("status")}, true
+	case mcp.EventClientError:
+		return sseEventFrame{Type: "client_status", Name: str("server_name"), Error: str("error")}, true
+	case mcp.EventClientAuthorizationRequired:
+		return sseEventFrame{Type: "authorization_required", Name: str("name"), URL: str("url")}, true
+	case mcp.EventClientDeviceAuthRequired:
+		return sseEventFrame{
+			Type:                    "authorization_required",
+			UserCode:                str("user_code"),
+			VerificationURI:         str("verification_uri"),
+			VerificationURIComplete: str("verification_uri_complete"),
+		}, true
+	case mcp.EventServersUpdated:
+		switch str("action") {
+		case "added":
+			return sseEventFrame{Type: "server_added", Name: str("added_server")}, true
+		case "updated":
+			return sseEventFrame{Type: "server_updated", Name: str("updated_server")}, true
+		case "removed":
+			return sseEventFrame{Type: "server_removed", Name: str("removed_server")}, true
+		default:
+			return sseEventFrame{}, false
+		}
+	default:
+		return sseEventFrame{}, false
+	}
+}
+
+// streamEvents serves GET /api/mcp/events as a Server-Sent Events stream, so
+// clients can notice OAuth authorization completions, restarts, and server
+// changes without polling /client-status and /servers. It subscribes to the
+// MCPService's event bus, writes a heartbeat comment every
+// sseHeartbeatInterval to keep the connection alive through idle-timing-out
+// proxies, and unsubscribes once the request context is cancelled.
+func (s *APIServer) streamEvents(c echo.Context) error {
+	events, unsubscribe := s.mcpService.SubscribeEvents()
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.WriteHeader(http.StatusOK)
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			frame, ok := translateSSEEvent(event)
+			if !ok {
+				continue
+			}
+			payload, err := json.Marshal(frame)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(res, "data: %s\n\n", payload); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
 func (s *APIServer) getSettings(c echo.Context) error {
 	settings, err := s.settingsService.GetSettings()
 	if err != nil {
@@ -138,3 +351,46 @@ func (s *APIServer) openConfigDirectory(c echo.Context) error {
 	}
 	return c.NoContent(http.StatusOK)
 }
+
+// createAPITokenRequest is the body for POST /api/tokens.
+type createAPITokenRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// createAPITokenResponse echoes the created token's metadata plus the raw
+// secret, which is shown here and only here.
+type createAPITokenResponse struct {
+	settings.APIToken
+	Token string `json:"token"`
+}
+
+func (s *APIServer) createAPIToken(c echo.Context) error {
+	var req createAPITokenRequest
+	if err := c.Bind(&req); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	scopes := make([]auth.Scope, len(req.Scopes))
+	for i, scope := range req.Scopes {
+		scopes[i] = auth.Scope(scope)
+	}
+
+	secret, token, err := s.authStore.Create(req.Name, scopes)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(http.StatusCreated, createAPITokenResponse{APIToken: token, Token: secret})
+}
+
+func (s *APIServer) listAPITokens(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.authStore.List())
+}
+
+func (s *APIServer) revokeAPIToken(c echo.Context) error {
+	id := c.Param("id")
+	if err := s.authStore.Revoke(id); err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+)
+
+// buildTLSConfig turns a settings.TLSConfig into a *tls.Config for Start's
+// listener, loading the certificate/key pair and, when configured, the
+// client CA bundle and verification mode for mTLS. Returns nil, nil when cfg
+// isn't enabled, so Start falls back to plain HTTP.
+func buildTLSConfig(cfg settings.TLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if cfg.ClientCAFile != "" {
+		pemBytes, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS client CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("no certificates found in TLS client CA bundle %q", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+	}
+
+	switch cfg.ClientAuthMode {
+	case settings.TLSClientAuthRequired:
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	case settings.TLSClientAuthVerifyIfGiven:
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	default:
+		tlsConfig.ClientAuth = tls.NoClientCert
+	}
+
+	return tlsConfig, nil
+}
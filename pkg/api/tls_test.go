@@ -0,0 +1,201 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+)
+
+// writeSelfSignedCert generates a self-signed ECDSA certificate for
+// "127.0.0.1", optionally signed by caKey/caCert instead of itself, and
+// writes the cert/key as PEM files under dir. Returns their paths.
+func writeSelfSignedCert(t *testing.T, dir, prefix string, caKey *ecdsa.PrivateKey, caCert *x509.Certificate) (certPath, keyPath string, cert *x509.Certificate, key *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: prefix},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		IsCA:                  caKey == nil,
+		BasicConstraintsValid: true,
+	}
+
+	signerKey, signerCert := key, template
+	if caKey != nil {
+		signerKey, signerCert = caKey, caCert
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+
+	certPath = filepath.Join(dir, prefix+"-cert.pem")
+	keyPath = filepath.Join(dir, prefix+"-key.pem")
+
+	certOut, err := os.Create(certPath)
+	if err != nil {
+		t.Fatalf("create cert file: %v", err)
+	}
+	defer certOut.Close()
+	if err := pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+		t.Fatalf("encode cert: %v", err)
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	keyOut, err := os.Create(keyPath)
+	if err != nil {
+		t.Fatalf("create key file: %v", err)
+	}
+	defer keyOut.Close()
+	if err := pem.Encode(keyOut, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}); err != nil {
+		t.Fatalf("encode key: %v", err)
+	}
+
+	return certPath, keyPath, cert, key
+}
+
+func TestBuildTLSConfigDisabled(t *testing.T) {
+	cfg, err := buildTLSConfig(settings.TLSConfig{})
+	if err != nil {
+		t.Fatalf("buildTLSConfig: %v", err)
+	}
+	if cfg != nil {
+		t.Fatalf("expected nil config when TLS is disabled, got %+v", cfg)
+	}
+}
+
+func TestBuildTLSConfigInvalidCert(t *testing.T) {
+	dir := t.TempDir()
+	_, err := buildTLSConfig(settings.TLSConfig{
+		CertFile: filepath.Join(dir, "missing-cert.pem"),
+		KeyFile:  filepath.Join(dir, "missing-key.pem"),
+	})
+	if err == nil {
+		t.Fatal("expected an error for a missing certificate/key pair")
+	}
+}
+
+// TestBuildTLSConfigServesHTTPS drives buildTLSConfig's output through a real
+// TLS handshake via httptest.NewUnstartedServer, mirroring how APIServer.Start
+// wires it into the REST listener.
+func TestBuildTLSConfigServesHTTPS(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath, cert, _ := writeSelfSignedCert(t, dir, "server", nil, nil)
+
+	tlsConfig, err := buildTLSConfig(settings.TLSConfig{CertFile: certPath, KeyFile: keyPath})
+	if err != nil {
+		t.Fatalf("buildTLSConfig: %v", err)
+	}
+	if tlsConfig == nil {
+		t.Fatal("expected a non-nil TLS config when cert/key are set")
+	}
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	ts.TLS = tlsConfig
+	ts.StartTLS()
+	defer ts.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+
+	resp, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("GET %s: %v", ts.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+// TestBuildTLSConfigRequiresClientCert covers the mTLS ClientAuthMode path:
+// a client without a certificate must be rejected when ClientAuthMode is
+// TLSClientAuthRequired, and accepted once it presents one signed by the
+// configured ClientCAFile.
+func TestBuildTLSConfigRequiresClientCert(t *testing.T) {
+	dir := t.TempDir()
+	caCertPath, _, caCert, caKey := writeSelfSignedCert(t, dir, "ca", nil, nil)
+	certPath, keyPath, _, _ := writeSelfSignedCert(t, dir, "server", nil, nil)
+	clientCertPath, clientKeyPath, _, _ := writeSelfSignedCert(t, dir, "client", caKey, caCert)
+
+	tlsConfig, err := buildTLSConfig(settings.TLSConfig{
+		CertFile:       certPath,
+		KeyFile:        keyPath,
+		ClientCAFile:   caCertPath,
+		ClientAuthMode: settings.TLSClientAuthRequired,
+	})
+	if err != nil {
+		t.Fatalf("buildTLSConfig: %v", err)
+	}
+	if tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Fatalf("expected RequireAndVerifyClientCert, got %v", tlsConfig.ClientAuth)
+	}
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	ts.TLS = tlsConfig
+	ts.StartTLS()
+	defer ts.Close()
+
+	serverPool := x509.NewCertPool()
+	serverPool.AddCert(ts.Certificate())
+
+	// No client certificate: the handshake must fail.
+	noCertClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: serverPool}}}
+	if _, err := noCertClient.Get(ts.URL); err == nil {
+		t.Fatal("expected handshake to fail without a client certificate")
+	}
+
+	// A client certificate signed by the configured CA must be accepted.
+	clientCert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+	if err != nil {
+		t.Fatalf("load client cert: %v", err)
+	}
+	withCertClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      serverPool,
+		Certificates: []tls.Certificate{clientCert},
+	}}}
+	resp, err := withCertClient.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("GET with client cert: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+}
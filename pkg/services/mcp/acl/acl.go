@@ -0,0 +1,87 @@
+// Package acl implements per-principal authorization for the bouncer's aggregated
+// tool surface: which upstream servers and tools a given incoming client is allowed
+// to see and call.
+package acl
+
+import (
+	"log/slog"
+	"path"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Principal identifies the caller of an incoming MCP request, derived from a bearer
+// token, mTLS certificate CN, or an X-MCP-Client-Id header.
+type Principal string
+
+// Rule grants a principal access to tools on ServerName (or "*" for every server)
+// whose name matches ToolGlob (a path.Match pattern, e.g. "read_*" or "*").
+type Rule struct {
+	ServerName string `json:"server_name"`
+	ToolGlob   string `json:"tool_glob"`
+}
+
+// Policy maps a principal to the set of rules it is allowed to use.
+type Policy struct {
+	Principal Principal `json:"principal"`
+	Rules     []Rule    `json:"rules"`
+}
+
+// ACL answers allow/deny questions for a fixed set of policies.
+type ACL struct {
+	policies map[Principal][]Rule
+}
+
+// New builds an ACL from settings-defined policies. A nil or empty ACL fails open,
+// so bouncers without any configured policies keep their current unrestricted
+// behavior.
+func New(policies []Policy) *ACL {
+	a := &ACL{policies: make(map[Principal][]Rule, len(policies))}
+	for _, p := range policies {
+		a.policies[p.Principal] = p.Rules
+	}
+	return a
+}
+
+func (a *ACL) allows(principal Principal, serverName, toolName string) bool {
+	if a == nil || len(a.policies) == 0 {
+		return true
+	}
+	rules, ok := a.policies[principal]
+	if !ok {
+		return false
+	}
+	for _, rule := range rules {
+		if rule.ServerName != "*" && rule.ServerName != serverName {
+			continue
+		}
+		if matched, _ := path.Match(rule.ToolGlob, toolName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed reports whether principal may invoke serverName's toolName. Used at
+// tools/call time, where only a yes/no answer is needed.
+func (a *ACL) Allowed(principal Principal, serverName, toolName string) bool {
+	return a.allows(principal, serverName, toolName)
+}
+
+// Filter returns the subset of serverName's tools principal is allowed to see,
+// logging an audit line for each tool dropped by policy.
+func (a *ACL) Filter(principal Principal, serverName string, tools []mcp.Tool) []mcp.Tool {
+	if a == nil || len(a.policies) == 0 {
+		return tools
+	}
+
+	filtered := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if a.allows(principal, serverName, tool.Name) {
+			filtered = append(filtered, tool)
+		} else {
+			slog.Info("dropping tool from result due to ACLs", "tool", tool.Name, "server_name", serverName, "principal", principal)
+		}
+	}
+	return filtered
+}
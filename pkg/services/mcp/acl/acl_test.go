@@ -0,0 +1,87 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestACLFailsOpenWithNoPolicies(t *testing.T) {
+	a := New(nil)
+
+	if !a.Allowed("anyone", "any-server", "any-tool") {
+		t.Fatal("expected an ACL with no configured policies to fail open")
+	}
+}
+
+func TestACLAllowedMatchesGlob(t *testing.T) {
+	a := New([]Policy{
+		{Principal: "alice", Rules: []Rule{{ServerName: "files", ToolGlob: "read_*"}}},
+	})
+
+	if !a.Allowed("alice", "files", "read_file") {
+		t.Fatal("expected alice to be allowed read_file on files per her rule")
+	}
+	if a.Allowed("alice", "files", "write_file") {
+		t.Fatal("expected alice to be denied write_file, which doesn't match read_*")
+	}
+	if a.Allowed("alice", "other-server", "read_file") {
+		t.Fatal("expected alice's rule scoped to 'files' not to apply to other-server")
+	}
+}
+
+func TestACLWildcardServerApplies(t *testing.T) {
+	a := New([]Policy{
+		{Principal: "bob", Rules: []Rule{{ServerName: "*", ToolGlob: "ping"}}},
+	})
+
+	if !a.Allowed("bob", "files", "ping") {
+		t.Fatal("expected a '*' server rule to match any server")
+	}
+	if !a.Allowed("bob", "other", "ping") {
+		t.Fatal("expected a '*' server rule to match any server")
+	}
+	if a.Allowed("bob", "files", "pong") {
+		t.Fatal("expected the tool glob to still be enforced under a wildcard server")
+	}
+}
+
+func TestACLDeniesUnknownPrincipal(t *testing.T) {
+	a := New([]Policy{
+		{Principal: "alice", Rules: []Rule{{ServerName: "*", ToolGlob: "*"}}},
+	})
+
+	if a.Allowed("mallory", "files", "read_file") {
+		t.Fatal("expected a principal with no policy entry to be denied once any policy is configured")
+	}
+}
+
+func TestACLFilterDropsDisallowedTools(t *testing.T) {
+	a := New([]Policy{
+		{Principal: "alice", Rules: []Rule{{ServerName: "files", ToolGlob: "read_*"}}},
+	})
+
+	tools := []mcp.Tool{{Name: "read_file"}, {Name: "write_file"}}
+	filtered := a.Filter("alice", "files", tools)
+
+	if len(filtered) != 1 || filtered[0].Name != "read_file" {
+		t.Fatalf("expected only read_file to survive the filter, got %+v", filtered)
+	}
+}
+
+func TestACLFilterPassesThroughWithNoPolicies(t *testing.T) {
+	a := New(nil)
+	tools := []mcp.Tool{{Name: "read_file"}, {Name: "write_file"}}
+
+	if filtered := a.Filter("anyone", "files", tools); len(filtered) != len(tools) {
+		t.Fatalf("expected Filter to pass every tool through with no configured policies, got %+v", filtered)
+	}
+}
+
+func TestACLNilReceiverFailsOpen(t *testing.T) {
+	var a *ACL
+
+	if !a.Allowed("anyone", "any-server", "any-tool") {
+		t.Fatal("expected a nil *ACL to fail open, matching New(nil)'s behavior")
+	}
+}
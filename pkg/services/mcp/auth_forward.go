@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type forwardedAuthContextKey struct{}
+
+// forwardedAuth carries the incoming session's identity to apply to a single
+// outgoing upstream request.
+type forwardedAuth struct {
+	Header string
+	Value  string
+}
+
+// withForwardedAuth returns a context that forwardingRoundTripper will read to
+// set auth.Header: auth.Value on the outgoing request.
+func withForwardedAuth(ctx context.Context, auth forwardedAuth) context.Context {
+	return context.WithValue(ctx, forwardedAuthContextKey{}, auth)
+}
+
+func forwardedAuthFromContext(ctx context.Context) (forwardedAuth, bool) {
+	auth, ok := ctx.Value(forwardedAuthContextKey{}).(forwardedAuth)
+	return auth, ok
+}
+
+// forwardingRoundTripper injects a per-call forwarded identity (set via
+// withForwardedAuth) onto outgoing requests, so one upstream http.Client can
+// serve both the bouncer's own credentials and forwarded incoming sessions.
+type forwardingRoundTripper struct {
+	next http.RoundTripper
+}
+
+func (t *forwardingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if auth, ok := forwardedAuthFromContext(req.Context()); ok {
+		req = req.Clone(req.Context())
+		req.Header.Set(auth.Header, auth.Value)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// withForwardingRoundTripper wraps base (nil meaning http.DefaultTransport) so
+// that per-call forwarded identities set via withForwardedAuth are honored.
+func withForwardingRoundTripper(base *http.Client) *http.Client {
+	next := http.DefaultTransport
+	if base != nil && base.Transport != nil {
+		next = base.Transport
+	}
+	return &http.Client{Transport: &forwardingRoundTripper{next: next}}
+}
+
+// authHeaderName returns the upstream header name to use for a forwarded
+// Authorization header, honoring cfg.AuthHeaderMap's "Authorization" entry.
+func authHeaderName(cfg map[string]string) string {
+	if mapped, ok := cfg["Authorization"]; ok && mapped != "" {
+		return mapped
+	}
+	return "Authorization"
+}
+
+// isAuthError reports whether err looks like an upstream authentication or
+// authorization rejection, used to decide whether to retry a forwarded call
+// with the bouncer's own credentials.
+func isAuthError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "401") ||
+		strings.Contains(msg, "403") ||
+		strings.Contains(msg, "unauthorized") ||
+		strings.Contains(msg, "forbidden")
+}
@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"sync"
+	"time"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+)
+
+// BreakerState is the circuit breaker's current position in the
+// Closed -> Open -> HalfOpen state machine.
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+const (
+	defaultErrorRateThreshold = 0.5
+	defaultMinRequests        = 20
+	defaultWindow             = 30 * time.Second
+	defaultCoolDown           = 30 * time.Second
+)
+
+type breakerResult struct {
+	at      time.Time
+	success bool
+}
+
+// circuitBreaker sheds calls to an upstream client once its rolling error rate
+// crosses a threshold, and probes for recovery after a cool-down period.
+type circuitBreaker struct {
+	mu               sync.Mutex
+	cfg              settings.CircuitBreakerConfig
+	state            BreakerState
+	results          []breakerResult
+	openedAt         time.Time
+	halfOpenInFlight bool
+}
+
+// newCircuitBreaker builds a breaker from config, filling in package defaults for
+// any zero-valued fields.
+func newCircuitBreaker(cfg settings.CircuitBreakerConfig) *circuitBreaker {
+	if cfg.ErrorRateThreshold <= 0 {
+		cfg.ErrorRateThreshold = defaultErrorRateThreshold
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = defaultMinRequests
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultWindow
+	}
+	if cfg.CoolDown <= 0 {
+		cfg.CoolDown = defaultCoolDown
+	}
+	return &circuitBreaker{state: BreakerClosed, cfg: cfg}
+}
+
+// Allow reports whether a call may proceed. Once Open, it admits a single probe
+// request as HalfOpen after the cool-down elapses and shifts to HalfOpen.
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cfg.CoolDown || b.halfOpenInFlight {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		b.halfOpenInFlight = true
+		return true
+	case BreakerHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// RecordResult records the outcome of a call admitted by Allow, returning the new
+// state and whether this call caused a transition.
+func (b *circuitBreaker) RecordResult(success bool) (newState BreakerState, transitioned bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+
+	if b.state == BreakerHalfOpen {
+		b.halfOpenInFlight = false
+		if success {
+			b.state = BreakerClosed
+			b.results = nil
+		} else {
+			b.state = BreakerOpen
+			b.openedAt = now
+		}
+		return b.state, true
+	}
+
+	b.results = append(b.results, breakerResult{at: now, success: success})
+	b.trim(now)
+
+	if b.state == BreakerClosed && len(b.results) >= b.cfg.MinRequests {
+		errored := 0
+		for _, r := range b.results {
+			if !r.success {
+				errored++
+			}
+		}
+		if float64(errored)/float64(len(b.results)) > b.cfg.ErrorRateThreshold {
+			b.state = BreakerOpen
+			b.openedAt = now
+			return b.state, true
+		}
+	}
+
+	return b.state, false
+}
+
+// trim drops results older than the rolling window, assuming the lock is held.
+func (b *circuitBreaker) trim(now time.Time) {
+	cutoff := now.Add(-b.cfg.Window)
+	i := 0
+	for ; i < len(b.results); i++ {
+		if b.results[i].at.After(cutoff) {
+			break
+		}
+	}
+	b.results = b.results[i:]
+}
+
+// State returns the breaker's current state.
+func (b *circuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
@@ -0,0 +1,98 @@
+package mcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+)
+
+func TestCircuitBreakerStaysClosedBelowMinRequests(t *testing.T) {
+	b := newCircuitBreaker(settings.CircuitBreakerConfig{MinRequests: 5, ErrorRateThreshold: 0.1})
+
+	for i := 0; i < 4; i++ {
+		if !b.Allow() {
+			t.Fatalf("call %d: expected breaker to still admit calls", i)
+		}
+		if state, _ := b.RecordResult(false); state != BreakerClosed {
+			t.Fatalf("call %d: expected Closed below MinRequests, got %s", i, state)
+		}
+	}
+}
+
+func TestCircuitBreakerOpensAfterErrorRateThreshold(t *testing.T) {
+	b := newCircuitBreaker(settings.CircuitBreakerConfig{MinRequests: 4, ErrorRateThreshold: 0.5})
+
+	for i := 0; i < 3; i++ {
+		b.Allow()
+		if state, _ := b.RecordResult(false); state != BreakerClosed {
+			t.Fatalf("call %d: expected Closed before MinRequests met, got %s", i, state)
+		}
+	}
+
+	b.Allow()
+	state, transitioned := b.RecordResult(false)
+	if state != BreakerOpen || !transitioned {
+		t.Fatalf("expected the 4th failure to open the breaker, got state=%s transitioned=%v", state, transitioned)
+	}
+
+	if b.Allow() {
+		t.Fatal("expected an open breaker to deny calls before the cool-down elapses")
+	}
+}
+
+func TestCircuitBreakerHalfOpenRecovery(t *testing.T) {
+	b := newCircuitBreaker(settings.CircuitBreakerConfig{MinRequests: 1, ErrorRateThreshold: 0.1, CoolDown: time.Millisecond})
+
+	b.Allow()
+	if state, _ := b.RecordResult(false); state != BreakerOpen {
+		t.Fatalf("expected a single failure past MinRequests to open the breaker, got %s", state)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if !b.Allow() {
+		t.Fatal("expected a probe call to be admitted once the cool-down elapsed")
+	}
+	if b.State() != BreakerHalfOpen {
+		t.Fatalf("expected the admitted probe to move the breaker to HalfOpen, got %s", b.State())
+	}
+	if b.Allow() {
+		t.Fatal("expected a second concurrent call to be denied while a probe is in flight")
+	}
+
+	state, transitioned := b.RecordResult(true)
+	if state != BreakerClosed || !transitioned {
+		t.Fatalf("expected a successful probe to close the breaker, got state=%s transitioned=%v", state, transitioned)
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbeFailureReopens(t *testing.T) {
+	b := newCircuitBreaker(settings.CircuitBreakerConfig{MinRequests: 1, ErrorRateThreshold: 0.1, CoolDown: time.Millisecond})
+
+	b.Allow()
+	b.RecordResult(false)
+	time.Sleep(5 * time.Millisecond)
+
+	if !b.Allow() {
+		t.Fatal("expected a probe call to be admitted once the cool-down elapsed")
+	}
+	state, transitioned := b.RecordResult(false)
+	if state != BreakerOpen || !transitioned {
+		t.Fatalf("expected a failed probe to reopen the breaker, got state=%s transitioned=%v", state, transitioned)
+	}
+}
+
+func TestCircuitBreakerWindowDropsStaleResults(t *testing.T) {
+	b := newCircuitBreaker(settings.CircuitBreakerConfig{MinRequests: 2, ErrorRateThreshold: 0.1, Window: 5 * time.Millisecond})
+
+	b.Allow()
+	b.RecordResult(false)
+
+	time.Sleep(10 * time.Millisecond)
+
+	b.Allow()
+	if state, _ := b.RecordResult(false); state != BreakerClosed {
+		t.Fatalf("expected the stale failure to have been trimmed from the window, got %s", state)
+	}
+}
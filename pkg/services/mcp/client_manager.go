@@ -1,23 +1,51 @@
 package mcp
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"math/rand"
+	"os"
 	"sync"
 	"time"
 
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/acl"
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/logging"
 	"github.com/catkins/mcp-bouncer/pkg/services/settings"
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// toolCallTracer emits the "mcp.tool.call" span wrapping every proxied tool
+// call in registerClientTools, so a configured OpenTelemetry exporter can show
+// upstream tool latency and errors alongside the rest of a request's trace.
+var toolCallTracer = otel.Tracer("github.com/catkins/mcp-bouncer/pkg/services/mcp")
+
 // ClientManager manages MCP client connections
 type ClientManager struct {
 	clients map[string]*ManagedClient
 	mutex   sync.RWMutex
 	server  *Server
+
+	// Logger is the root logger every ManagedClient's logger is derived from
+	// in StartClient. Set via NewClientManagerWithOptions so, e.g., one
+	// ClientManager's traffic can be shipped to a JSON sink while another's
+	// stays on the process's default text logger.
+	Logger *slog.Logger
+
+	// Metrics records the outcome of every proxied tool call. Defaults to the
+	// Prometheus-backed implementation in metrics.go; set via
+	// ClientManagerOptions.Metrics to substitute another backend or a no-op
+	// for tests.
+	Metrics ToolMetrics
 }
 
 // ManagedClient represents a managed MCP client connection
@@ -30,30 +58,106 @@ type ManagedClient struct {
 	LastError             string
 	AuthorizationRequired bool
 	OAuthAuthenticated    bool
+	Breaker               *circuitBreaker
+	Metrics               *clientMetrics
 	StopChan              chan struct{}
 	RestartChan           chan struct{}
+
+	// Logger is derived in StartClient from the owning ClientManager's
+	// Logger, with "server_name" and "transport" already bound.
+	Logger *slog.Logger
+
+	// OAuthRedirectURI is reserved in StartClient for TransportStreamableHTTP
+	// clients that RequiresAuth, so the loopback port baked into the OAuth
+	// client's RedirectURI at construction time matches the port
+	// AuthorizeClient later re-binds to serve the callback.
+	OAuthRedirectURI string
+
+	// RestartAttempts, NextBackoff, and LastHealthyAt track monitorClient's
+	// reconnect state across ticks: RestartAttempts counts the reconnects
+	// monitorClient has begun for this ManagedClient, NextBackoff is the delay
+	// the next one would wait (reset to the configured initial value on a
+	// healthy probe), and LastHealthyAt is the last time a health check
+	// succeeded. A reconnect replaces the ManagedClient through RestartClient,
+	// so all three start over on the new one.
+	RestartAttempts int
+	NextBackoff     time.Duration
+	LastHealthyAt   time.Time
 }
 
 // ClientStatus represents the status of a client
 type ClientStatus struct {
-	Name                  string `json:"name"`
-	Connected             bool   `json:"connected"`
-	Tools                 int    `json:"tools"`
-	LastError             string `json:"last_error,omitempty"`
-	AuthorizationRequired bool   `json:"authorization_required"`
-	OAuthAuthenticated    bool   `json:"oauth_authenticated"`
+	Name                  string       `json:"name"`
+	Connected             bool         `json:"connected"`
+	Tools                 int          `json:"tools"`
+	LastError             string       `json:"last_error,omitempty"`
+	AuthorizationRequired bool         `json:"authorization_required"`
+	OAuthAuthenticated    bool         `json:"oauth_authenticated"`
+	BreakerState          BreakerState `json:"breaker_state"`
+	ConsecutiveFailures   int          `json:"consecutive_failures"`
+	LastSuccessAt         time.Time    `json:"last_success_at,omitempty"`
+	LatencyP50Ms          int64        `json:"latency_p50_ms,omitempty"`
+	LatencyP99Ms          int64        `json:"latency_p99_ms,omitempty"`
+	SocketPath            string       `json:"socket_path,omitempty"`
+}
+
+// ClientManagerOptions configures a ClientManager built via
+// NewClientManagerWithOptions.
+type ClientManagerOptions struct {
+	// Logger, if set, is used as the ClientManager's root logger as-is.
+	// Level and Format are ignored when Logger is set.
+	Logger *slog.Logger
+
+	// Level sets the minimum level of the handler built from Format.
+	Level slog.Level
+
+	// Format selects the encoding of the handler built when Logger is nil.
+	Format settings.LogFormat
+
+	// Metrics, if set, replaces the default Prometheus-backed ToolMetrics.
+	Metrics ToolMetrics
 }
 
 // NewClientManager creates a new client manager
 func NewClientManager(server *Server) *ClientManager {
+	return NewClientManagerWithOptions(server, ClientManagerOptions{Logger: slog.Default()})
+}
+
+// NewClientManagerWithOptions creates a new client manager whose Logger is
+// either opts.Logger, or a handler built from opts.Level and opts.Format if
+// opts.Logger is nil. This lets operators route one server's logs to a JSON
+// sink for ingestion while another stays on the process default.
+func NewClientManagerWithOptions(server *Server, opts ClientManagerOptions) *ClientManager {
+	logger := opts.Logger
+	if logger == nil {
+		handlerOpts := &slog.HandlerOptions{Level: opts.Level}
+		var handler slog.Handler
+		if opts.Format == settings.LogFormatJSON {
+			handler = slog.NewJSONHandler(os.Stderr, handlerOpts)
+		} else {
+			handler = slog.NewTextHandler(os.Stderr, handlerOpts)
+		}
+		logger = slog.New(handler)
+	}
+
+	metrics := opts.Metrics
+	if metrics == nil {
+		metrics = prometheusToolMetrics{}
+	}
+
 	return &ClientManager{
 		clients: make(map[string]*ManagedClient),
 		server:  server,
+		Logger:  logger.With("component", "mcp.client_manager"),
+		Metrics: metrics,
 	}
 }
 
 // StartClient starts an MCP client based on configuration
 func (cm *ClientManager) StartClient(ctx context.Context, config settings.MCPServerConfig) error {
+	ctx = logging.WithClient(ctx, config.Name)
+	logger := logging.FromContext(ctx)
+
 	cm.mutex.Lock()
 	defer cm.mutex.Unlock()
 
@@ -70,8 +174,11 @@ func (cm *ClientManager) StartClient(ctx context.Context, config settings.MCPSer
 	mc := &ManagedClient{
 		Config:      config,
 		Connected:   false,
+		Breaker:     newCircuitBreaker(config.CircuitBreaker),
+		Metrics:     newClientMetrics(),
 		StopChan:    make(chan struct{}),
 		RestartChan: make(chan struct{}),
+		Logger:      cm.Logger.With("server_name", config.Name, "transport", config.Transport),
 	}
 
 	// Start the client process and create transport
@@ -100,7 +207,7 @@ func (cm *ClientManager) StartClient(ctx context.Context, config settings.MCPSer
 			mc.LastError = fmt.Sprintf("** cm.initializeClient: %s", err.Error())
 			cm.clients[config.Name] = mc
 
-			slog.Warn("Client requires authorization", "name", config.Name, "error", err)
+			mc.Logger.Warn("Client requires authorization", "error", err)
 
 			cm.server.EmitEvent("mcp:client_status_changed", map[string]any{
 				"server_name": config.Name,
@@ -121,10 +228,21 @@ func (cm *ClientManager) StartClient(ctx context.Context, config settings.MCPSer
 
 	mc.Connected = true
 	cm.clients[config.Name] = mc
+	metricsConnected.WithLabelValues(config.Name).Set(1)
+
+	cm.server.stateManager.RecordClient(PersistedClientState{
+		Name:               config.Name,
+		PID:                stdioChildPID(mc.Transport),
+		OAuthAuthenticated: mc.OAuthAuthenticated,
+		LastHealthy:        time.Now(),
+	})
 
 	go cm.monitorClient(ctx, mc)
+	if mc.Config.Transport == settings.TransportStdio {
+		go cm.watchStdioProcess(mc)
+	}
 
-	slog.Info("Started MCP client", "name", config.Name, "tools", len(mc.Tools))
+	logger.Info("Started MCP client", "tools", len(mc.Tools))
 
 	cm.server.EmitEvent("mcp:client_status_changed", map[string]any{
 		"server_name": config.Name,
@@ -135,6 +253,9 @@ func (cm *ClientManager) StartClient(ctx context.Context, config settings.MCPSer
 
 // StopClient stops an MCP client
 func (cm *ClientManager) StopClient(name string) error {
+	logger := logging.FromContext(logging.WithClient(context.Background(), name))
+	logger.Debug("Stopping client")
+
 	cm.mutex.Lock()
 	defer cm.mutex.Unlock()
 	return cm.stopClientInternal(name)
@@ -145,7 +266,7 @@ func (cm *ClientManager) stopClientInternal(name string) error {
 	mc, exists := cm.clients[name]
 	if !exists {
 		// Client doesn't exist, which is fine when disabling a server
-		slog.Debug("Client not found when stopping", "name", name)
+		cm.Logger.Debug("Client not found when stopping", "server_name", name)
 		return nil
 	}
 
@@ -163,15 +284,17 @@ func (cm *ClientManager) stopClientInternal(name string) error {
 		// Wait for transport close with timeout
 		select {
 		case <-done:
-			slog.Debug("Transport closed successfully", "name", name)
+			mc.Logger.Debug("Transport closed successfully")
 		case <-time.After(5 * time.Second):
-			slog.Warn("Transport close timed out", "name", name)
+			mc.Logger.Warn("Transport close timed out")
 		}
 	}
 
 	delete(cm.clients, name)
+	cm.server.stateManager.ForgetClient(name)
+	metricsConnected.WithLabelValues(name).Set(0)
 
-	slog.Info("Stopped MCP client", "name", name)
+	mc.Logger.Info("Stopped MCP client")
 
 	cm.server.EmitEvent("mcp:client_status_changed", map[string]any{
 		"server_name": name,
@@ -182,6 +305,9 @@ func (cm *ClientManager) stopClientInternal(name string) error {
 
 // RestartClient restarts an MCP client
 func (cm *ClientManager) RestartClient(ctx context.Context, name string) error {
+	ctx = logging.WithClient(ctx, name)
+	logging.FromContext(ctx).Info("Restarting client")
+
 	cm.mutex.Lock()
 	mc, exists := cm.clients[name]
 	if !exists {
@@ -194,14 +320,23 @@ func (cm *ClientManager) RestartClient(ctx context.Context, name string) error {
 		return fmt.Errorf("client '%s' not found", name)
 	}
 	config := mc.Config
+	settleDelay := time.Second
+	if mc.RestartAttempts > 0 {
+		// monitorClient already waited out the reconnect backoff before
+		// calling us; don't pile a flat extra second on top of every one of
+		// a crash-looping upstream's attempts.
+		settleDelay = 0
+	}
 	cm.mutex.Unlock()
 
 	if err := cm.StopClient(name); err != nil {
 		return fmt.Errorf("failed to stop client: %w", err)
 	}
 
-	// wait a bit before restarting
-	time.Sleep(1 * time.Second)
+	// Give the transport a moment to fully settle before restarting.
+	if settleDelay > 0 {
+		time.Sleep(settleDelay)
+	}
 
 	return cm.StartClient(ctx, config)
 }
@@ -236,18 +371,23 @@ func (cm *ClientManager) AuthorizeClient(ctx context.Context, name string) error
 		return fmt.Errorf("authorization not required or unexpected error: %w", err)
 	}
 
+	if mc.Config.OAuthFlow == settings.OAuthFlowDevice {
+		if err := cm.authorizeClientDeviceFlow(ctx, mc); err != nil {
+			mc.LastError = fmt.Sprintf("device authorization failed: %s", err)
+			return fmt.Errorf("device authorization failed: %w", err)
+		}
+		return cm.finishAuthorization(ctx, mc, name)
+	}
+
 	oauthHandler := client.GetOAuthHandler(err)
 	if oauthHandler == nil {
 		mc.LastError = "failed to obtain OAuth handler"
 		return fmt.Errorf("failed to obtain OAuth handler")
 	}
 
-	// Start callback server
-	callbackChan := make(chan map[string]string)
-	srv := startOAuthCallbackServer(callbackChan)
-	defer srv.Close()
-
-	// PKCE and state
+	// PKCE and state, generated before the callback server starts so the
+	// server can reject any callback that doesn't carry our state (CSRF
+	// protection) instead of trusting whatever hits the redirect URI.
 	codeVerifier, err := client.GenerateCodeVerifier()
 	if err != nil {
 		mc.LastError = fmt.Sprintf("failed to generate code verifier: %s", err)
@@ -260,7 +400,28 @@ func (cm *ClientManager) AuthorizeClient(ctx context.Context, name string) error
 		return fmt.Errorf("failed to generate state: %w", err)
 	}
 
-	if err := oauthHandler.RegisterClient(ctx, "mcp-bouncer"); err != nil {
+	if mc.OAuthRedirectURI == "" {
+		mc.LastError = "no oauth redirect URI reserved for this client"
+		return fmt.Errorf("no oauth redirect URI reserved for client '%s'", name)
+	}
+
+	// Bind the callback server to the port StartClient reserved, already
+	// baked into mc.OAuthRedirectURI (and therefore into the OAuth client's
+	// config), so the authorization URL generated below and the callback we
+	// receive agree on where to land.
+	callbackChan := make(chan map[string]string, 1)
+	srv, err := startOAuthCallbackServer(mc.OAuthRedirectURI, callbackChan, state, oauthCallbackTimeout)
+	if err != nil {
+		mc.LastError = fmt.Sprintf("failed to start oauth callback server: %s", err)
+		return fmt.Errorf("failed to start oauth callback server: %w", err)
+	}
+	defer srv.Close()
+
+	clientName := "mcp-bouncer"
+	if mc.Config.OAuth != nil && mc.Config.OAuth.ClientName != "" {
+		clientName = mc.Config.OAuth.ClientName
+	}
+	if err := registerOAuthClient(ctx, oauthHandler, clientName, mc.Config.OAuth); err != nil {
 		mc.LastError = fmt.Sprintf("failed to register client: %s", err)
 		return fmt.Errorf("failed to register client: %w", err)
 	}
@@ -271,13 +432,27 @@ func (cm *ClientManager) AuthorizeClient(ctx context.Context, name string) error
 		return fmt.Errorf("failed to get authorization URL: %w", err)
 	}
 
+	cm.server.EmitEvent(EventClientAuthorizationRequired, map[string]any{
+		"name": name,
+		"url":  authURL,
+	})
+
 	if err := openDefaultBrowser(authURL); err != nil {
 		mc.LastError = fmt.Sprintf("failed to open browser automatically: %s", err)
-		slog.Warn("Failed to open browser automatically", "error", err, "url", authURL)
+		mc.Logger.Warn("Failed to open browser automatically", "error", err, "url", authURL)
 	}
 
-	// Wait for callback
-	params := <-callbackChan
+	// Wait for callback, bounded so a user who never completes the browser
+	// flow doesn't leak the callback server and block this goroutine forever.
+	var params map[string]string
+	select {
+	case params = <-callbackChan:
+	case <-time.After(oauthCallbackTimeout):
+		mc.LastError = "timed out waiting for OAuth callback"
+		return fmt.Errorf("timed out waiting for OAuth callback")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 	if params["state"] != state {
 		return fmt.Errorf("state mismatch: expected %s, got %s", state, params["state"])
 	}
@@ -291,6 +466,94 @@ func (cm *ClientManager) AuthorizeClient(ctx context.Context, name string) error
 		return fmt.Errorf("failed to process authorization response: %w", err)
 	}
 
+	return cm.finishAuthorization(ctx, mc, name)
+}
+
+// oauthClientMetadataRegisterer is satisfied by an OAuth handler that can
+// submit richer RFC 7591 dynamic client registration metadata than a bare
+// client name. mark3labs/mcp-go's handler doesn't implement it today, so
+// registerOAuthClient falls back to RegisterClient whenever it doesn't.
+type oauthClientMetadataRegisterer interface {
+	RegisterClientWithMetadata(ctx context.Context, clientName string, metadata map[string]any) error
+}
+
+// registerOAuthClient registers clientName with oauthHandler, submitting
+// cfg's ClientURI, LogoURI, SoftwareID, SoftwareVersion, and
+// AdditionalMetadata alongside it when the handler supports
+// oauthClientMetadataRegisterer. cfg may be nil.
+func registerOAuthClient(ctx context.Context, oauthHandler interface {
+	RegisterClient(ctx context.Context, clientName string) error
+}, clientName string, cfg *settings.OAuthSettings) error {
+	metadata := oauthRegistrationMetadata(cfg)
+	if registerer, ok := oauthHandler.(oauthClientMetadataRegisterer); ok && len(metadata) > 0 {
+		return registerer.RegisterClientWithMetadata(ctx, clientName, metadata)
+	}
+	return oauthHandler.RegisterClient(ctx, clientName)
+}
+
+// oauthRegistrationMetadata builds the RFC 7591 metadata fields cfg
+// overrides, for use by registerOAuthClient. Returns nil if cfg is nil or
+// sets none of them.
+func oauthRegistrationMetadata(cfg *settings.OAuthSettings) map[string]any {
+	if cfg == nil {
+		return nil
+	}
+	metadata := make(map[string]any, len(cfg.AdditionalMetadata)+4)
+	for k, v := range cfg.AdditionalMetadata {
+		metadata[k] = v
+	}
+	if cfg.ClientURI != "" {
+		metadata["client_uri"] = cfg.ClientURI
+	}
+	if cfg.LogoURI != "" {
+		metadata["logo_uri"] = cfg.LogoURI
+	}
+	if cfg.SoftwareID != "" {
+		metadata["software_id"] = cfg.SoftwareID
+	}
+	if cfg.SoftwareVersion != "" {
+		metadata["software_version"] = cfg.SoftwareVersion
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// authorizeClientDeviceFlow runs the RFC 8628 device authorization grant for
+// mc and persists the resulting token via the configured TokenStore, as an
+// alternative to the loopback-callback browser flow above for servers that
+// set OAuthFlow to OAuthFlowDevice.
+func (cm *ClientManager) authorizeClientDeviceFlow(ctx context.Context, mc *ManagedClient) error {
+	scopes := []string{"mcp.read", "mcp.write"}
+	if mc.Config.OAuth != nil && len(mc.Config.OAuth.Scopes) > 0 {
+		scopes = mc.Config.OAuth.Scopes
+	}
+
+	authorizer := NewDeviceFlowAuthorizer(
+		mc.Config.DeviceAuthorizationEndpoint,
+		mc.Config.TokenEndpoint,
+		mc.Config.OAuthClientID,
+		scopes,
+	)
+
+	token, err := authorizer.Authorize(ctx, cm.server.EmitEvent)
+	if err != nil {
+		return err
+	}
+
+	if err := NewSecureTokenStore(mc.Config.Name).SaveToken(token); err != nil {
+		return fmt.Errorf("failed to save device flow token: %w", err)
+	}
+
+	return nil
+}
+
+// finishAuthorization performs the steps common to every OAuth flow once a
+// token has been obtained and stored: clearing the authorization-required
+// flag, re-initializing the client, re-registering its tools, and notifying
+// the UI.
+func (cm *ClientManager) finishAuthorization(ctx context.Context, mc *ManagedClient, name string) error {
 	// Authorization succeeded; clear flag and attempt initialize on existing client
 	cm.mutex.Lock()
 	mc.AuthorizationRequired = false
@@ -332,6 +595,16 @@ func (cm *ClientManager) GetClientStatus() map[string]ClientStatus {
 
 	status := make(map[string]ClientStatus)
 	for name, mc := range cm.clients {
+		breakerState := BreakerClosed
+		if mc.Breaker != nil {
+			breakerState = mc.Breaker.State()
+		}
+		var consecutiveFailures int
+		var lastSuccessAt time.Time
+		var p50, p99 time.Duration
+		if mc.Metrics != nil {
+			consecutiveFailures, lastSuccessAt, p50, p99 = mc.Metrics.Snapshot()
+		}
 		status[name] = ClientStatus{
 			Name:                  name,
 			Connected:             mc.Connected,
@@ -339,6 +612,12 @@ func (cm *ClientManager) GetClientStatus() map[string]ClientStatus {
 			LastError:             mc.LastError,
 			AuthorizationRequired: mc.AuthorizationRequired,
 			OAuthAuthenticated:    mc.OAuthAuthenticated,
+			BreakerState:          breakerState,
+			ConsecutiveFailures:   consecutiveFailures,
+			LastSuccessAt:         lastSuccessAt,
+			LatencyP50Ms:          p50.Milliseconds(),
+			LatencyP99Ms:          p99.Milliseconds(),
+			SocketPath:            mc.Config.SocketPath,
 		}
 	}
 	return status
@@ -361,6 +640,16 @@ func (cm *ClientManager) startClientProcess(mc *ManagedClient) error {
 		if mc.Config.Headers != nil {
 			options = append(options, transport.WithHeaders(mc.Config.Headers))
 		}
+		proxyClient, err := buildProxyHTTPClient(mc.Config)
+		if err != nil {
+			return fmt.Errorf("failed to configure proxy: %w", err)
+		}
+		if mc.Config.ForwardIncomingAuth {
+			proxyClient = withForwardingRoundTripper(proxyClient)
+		}
+		if proxyClient != nil {
+			options = append(options, transport.WithHTTPClient(proxyClient))
+		}
 
 		sseTransport, err := transport.NewSSE(mc.Config.Endpoint, options...)
 		if err != nil {
@@ -374,16 +663,53 @@ func (cm *ClientManager) startClientProcess(mc *ManagedClient) error {
 			return fmt.Errorf("endpoint is required for streamable HTTP transport")
 		}
 
+		proxyClient, err := buildProxyHTTPClient(mc.Config)
+		if err != nil {
+			return fmt.Errorf("failed to configure proxy: %w", err)
+		}
+		if mc.Config.ForwardIncomingAuth {
+			proxyClient = withForwardingRoundTripper(proxyClient)
+		}
+
 		if mc.Config.RequiresAuth {
-			// Use file-based token store for persistent OAuth tokens
-			tokenStore := NewFileTokenStore(mc.Config.Name)
-			slog.Debug("Creating OAuth client", "server_name", mc.Config.Name, "endpoint", mc.Config.Endpoint)
+			// Use the configured token store for persistent OAuth tokens
+			tokenStore := NewSecureTokenStore(mc.Config.Name)
+			mc.Logger.Debug("Creating OAuth client", "endpoint", mc.Config.Endpoint)
+
+			// Reserve the redirect URI's port now, before the OAuth client is
+			// built, so its RedirectURI bakes in the port AuthorizeClient
+			// will later bind the callback server to. Probing a free port
+			// (rather than a fixed one) is what lets several clients be
+			// authorized concurrently without colliding. A per-server
+			// OAuth.RedirectURI override skips the reservation and is used
+			// as-is; startOAuthCallbackServer binds to whatever host/port/path
+			// it encodes.
+			redirectURI := ""
+			if mc.Config.OAuth != nil {
+				redirectURI = mc.Config.OAuth.RedirectURI
+			}
+			if redirectURI == "" {
+				redirectURI, err = reserveOAuthCallbackRedirectURI()
+				if err != nil {
+					return fmt.Errorf("failed to reserve oauth callback redirect URI: %w", err)
+				}
+			}
+			mc.OAuthRedirectURI = redirectURI
+
+			scopes := []string{"mcp.read", "mcp.write"}
+			if mc.Config.OAuth != nil && len(mc.Config.OAuth.Scopes) > 0 {
+				scopes = mc.Config.OAuth.Scopes
+			}
+
 			oauthConfig := client.OAuthConfig{
-				RedirectURI: "http://localhost:8085/oauth/callback",
-				Scopes:      []string{"mcp.read", "mcp.write"},
+				RedirectURI: redirectURI,
+				Scopes:      scopes,
 				TokenStore:  tokenStore,
 				PKCEEnabled: true,
 			}
+			if proxyClient != nil {
+				oauthConfig.HTTPClient = proxyClient
+			}
 			oauthClient, err := client.NewOAuthStreamableHttpClient(mc.Config.Endpoint, oauthConfig)
 			if err != nil {
 				return fmt.Errorf("failed to create OAuth HTTP client: %w", err)
@@ -391,8 +717,12 @@ func (cm *ClientManager) startClientProcess(mc *ManagedClient) error {
 			mc.Client = oauthClient
 		} else {
 			// Create non-OAuth streamable HTTP client
-			slog.Debug("Creating non-OAuth streamable HTTP client", "server_name", mc.Config.Name, "endpoint", mc.Config.Endpoint)
-			httpClient, err := client.NewStreamableHttpClient(mc.Config.Endpoint)
+			mc.Logger.Debug("Creating non-OAuth streamable HTTP client", "endpoint", mc.Config.Endpoint)
+			var options []transport.StreamableHTTPCOption
+			if proxyClient != nil {
+				options = append(options, transport.WithHTTPBasicClient(proxyClient))
+			}
+			httpClient, err := client.NewStreamableHttpClient(mc.Config.Endpoint, options...)
 			if err != nil {
 				return fmt.Errorf("failed to create HTTP client: %w", err)
 			}
@@ -400,6 +730,26 @@ func (cm *ClientManager) startClientProcess(mc *ManagedClient) error {
 		}
 		return nil
 
+	case settings.TransportUnix:
+		if mc.Config.SocketPath == "" {
+			return fmt.Errorf("socket_path is required for unix transport")
+		}
+		if err := checkUnixSocketPermissions(mc.Config.SocketPath, mc.Config.AllowWorldWritableSocket); err != nil {
+			return err
+		}
+
+		udsClient := buildUnixSocketHTTPClient(mc.Config.SocketPath, mc.Config.Headers)
+		if mc.Config.ForwardIncomingAuth {
+			udsClient = withForwardingRoundTripper(udsClient)
+		}
+
+		httpClient, err := client.NewStreamableHttpClient(unixSocketEndpoint(mc.Config), transport.WithHTTPBasicClient(udsClient))
+		if err != nil {
+			return fmt.Errorf("failed to create unix socket HTTP client: %w", err)
+		}
+		mc.Client = httpClient
+		return nil
+
 	default:
 		return fmt.Errorf("unsupported transport type: %s", mc.Config.Transport)
 	}
@@ -432,33 +782,110 @@ func (cm *ClientManager) registerClientTools(ctx context.Context, mc *ManagedCli
 
 	// Register each tool with the main server
 	for _, tool := range listToolsResult.Tools {
-		// Create a proxy handler that forwards calls to the client
-		handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			// Strip the prefix from the tool name for the client call
-			request.Params.Name = tool.Name
+		cm.registerTool(mc, tool)
+	}
+
+	// Re-apply any per-tool toggles ToggleTool persisted before this client
+	// was last stopped, so a restart doesn't silently re-expose a tool an
+	// operator turned off.
+	if persisted, ok := cm.server.stateManager.Snapshot().Clients[mc.Config.Name]; ok {
+		for _, toolName := range persisted.DisabledTools {
+			cm.server.mcp.DeleteTools(fmt.Sprintf("%s:%s", mc.Config.Name, toolName))
+		}
+	}
+
+	return nil
+}
+
+// registerTool adds tool to the main server under its prefixed name, wired
+// through toolCallHandler so ACL enforcement, the tool exposure policy, the
+// circuit breaker, auth forwarding, and tracing/metrics apply the same way
+// regardless of whether the tool is being registered at startup
+// (registerClientTools) or re-enabled after being toggled off (ToggleTool).
+func (cm *ClientManager) registerTool(mc *ManagedClient, tool mcp.Tool) {
+	prefixedName := fmt.Sprintf("%s:%s", mc.Config.Name, tool.Name)
+
+	prefixedTool := mcp.Tool{
+		Name:        prefixedName,
+		Description: fmt.Sprintf("[%s] %s", mc.Config.Name, tool.Description),
+		InputSchema: tool.InputSchema,
+	}
 
-			slog.Info("Calling tool",
-				"client", mc.Config.Name,
-				"original_tool", tool.Name,
-				"prefixed_tool", request.Params.Name,
-				"request", request)
+	cm.server.mcp.AddTool(prefixedTool, cm.toolCallHandler(mc, tool, prefixedName))
+	mc.Logger.Debug("Registered client tool", "tool", tool.Name, "prefixed_name", prefixedName)
+}
 
-			return mc.Client.CallTool(ctx, request)
+// toolCallHandler builds the proxy handler for tool on mc, enforcing the
+// principal ACL, the tool exposure policy, and the circuit breaker before
+// forwarding the call upstream, and recording tracing, metrics, and breaker
+// state around it.
+func (cm *ClientManager) toolCallHandler(mc *ManagedClient, tool mcp.Tool, prefixedName string) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		principal := principalFromContext(ctx)
+		if !cm.server.acl.Allowed(principal, mc.Config.Name, tool.Name) {
+			mc.Logger.Info("denying tools/call due to ACLs", "tool", tool.Name, "principal", principal)
+			return nil, fmt.Errorf("principal %q is not authorized to call tool %q on server %q", principal, tool.Name, mc.Config.Name)
 		}
 
-		// Add tool to main server with prefixed name to avoid conflicts
-		prefixedName := fmt.Sprintf("%s:%s", mc.Config.Name, tool.Name)
-		prefixedTool := mcp.Tool{
-			Name:        prefixedName,
-			Description: fmt.Sprintf("[%s] %s", mc.Config.Name, tool.Description),
-			InputSchema: tool.InputSchema,
+		clientName := ""
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			if ic, ok := cm.server.incomingClients.Get(session.SessionID()); ok {
+				clientName = ic.Name
+			}
+		}
+		if !cm.server.toolPolicy.Allowed(prefixedName, clientName) {
+			mc.Logger.Info("denying tools/call due to tool policy", "tool", prefixedName, "client", clientName)
+			return nil, fmt.Errorf("tool %q is not exposed to client %q by policy", prefixedName, clientName)
 		}
 
-		cm.server.mcp.AddTool(prefixedTool, handler)
-		slog.Debug("Registered client tool", "client", mc.Config.Name, "tool", tool.Name, "prefixed_name", prefixedName)
-	}
+		if !mc.Breaker.Allow() {
+			return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, mc.Config.Name)
+		}
 
-	return nil
+		// Strip the prefix from the tool name for the client call
+		request.Params.Name = tool.Name
+
+		mc.Logger.Info("Calling tool",
+			"original_tool", tool.Name,
+			"prefixed_tool", prefixedName,
+			"request", request)
+
+		spanCtx, span := toolCallTracer.Start(ctx, "mcp.tool.call", trace.WithAttributes(
+			attribute.String("mcp.client", mc.Config.Name),
+			attribute.String("mcp.tool", tool.Name),
+			attribute.String("mcp.transport", string(mc.Config.Transport)),
+		))
+		defer span.End()
+
+		callCtx := spanCtx
+		forwarded := false
+		if mc.Config.ForwardIncomingAuth {
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				if authHeader, ok := cm.server.incomingClients.AuthHeader(session.SessionID()); ok {
+					callCtx = withForwardedAuth(callCtx, forwardedAuth{Header: authHeaderName(mc.Config.AuthHeaderMap), Value: authHeader})
+					forwarded = true
+				}
+			}
+		}
+
+		start := time.Now()
+		result, err := mc.Client.CallTool(callCtx, request)
+		if forwarded && isAuthError(err) {
+			mc.Logger.Warn("Upstream rejected forwarded session identity; retrying with bouncer's own credentials",
+				"tool", tool.Name)
+			result, err = mc.Client.CallTool(spanCtx, request)
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		cm.Metrics.ObserveToolCall(mc.Config.Name, tool.Name, time.Since(start), err)
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			cm.server.incomingClients.RecordToolCall(session.SessionID(), mc.Config.Name, tool.Name)
+		}
+		cm.recordBreakerResult(mc, err == nil)
+		return result, err
+	}
 }
 
 // removeClientTools removes tools from the main server
@@ -466,62 +893,261 @@ func (cm *ClientManager) removeClientTools(mc *ManagedClient) {
 	for _, tool := range mc.Tools {
 		prefixedName := fmt.Sprintf("%s:%s", mc.Config.Name, tool.Name)
 		cm.server.mcp.DeleteTools(prefixedName)
-		slog.Debug("Removed client tool", "client", mc.Config.Name, "tool", tool.Name, "prefixed_name", prefixedName)
+		mc.Logger.Debug("Removed client tool", "tool", tool.Name, "prefixed_name", prefixedName)
 	}
 	mc.Tools = nil
 }
 
+// recordBreakerResult feeds a call outcome into mc's circuit breaker and emits a
+// breaker event for the UI when the breaker trips open or recovers closed.
+func (cm *ClientManager) recordBreakerResult(mc *ManagedClient, success bool) {
+	newState, transitioned := mc.Breaker.RecordResult(success)
+	if !transitioned {
+		return
+	}
+
+	switch newState {
+	case BreakerOpen:
+		mc.Logger.Warn("Circuit breaker tripped open")
+		cm.server.EmitEvent(EventClientBreakerOpen, map[string]any{
+			"server_name": mc.Config.Name,
+		})
+	case BreakerClosed:
+		mc.Logger.Info("Circuit breaker closed")
+		cm.server.EmitEvent(EventClientBreakerClosed, map[string]any{
+			"server_name": mc.Config.Name,
+		})
+	}
+}
+
 // monitorClient monitors the client for disconnections and restarts
+const (
+	defaultHealthInterval          = 15 * time.Second
+	defaultHealthTimeout           = 5 * time.Second
+	defaultHealthFailureThreshold  = 3
+	defaultHealthBackoffInitial    = time.Second
+	defaultHealthBackoffMax        = 30 * time.Second
+	defaultHealthBackoffMultiplier = 2.0
+)
+
+// healthCheckDefaults fills any zero-valued fields of cfg with the package
+// defaults, mirroring newCircuitBreaker's zero-value handling.
+func healthCheckDefaults(cfg settings.HealthCheckConfig) settings.HealthCheckConfig {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultHealthInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultHealthTimeout
+	}
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultHealthFailureThreshold
+	}
+	if cfg.BackoffInitial <= 0 {
+		cfg.BackoffInitial = defaultHealthBackoffInitial
+	}
+	if cfg.BackoffMax <= 0 {
+		cfg.BackoffMax = defaultHealthBackoffMax
+	}
+	if cfg.BackoffMultiplier <= 0 {
+		cfg.BackoffMultiplier = defaultHealthBackoffMultiplier
+	}
+	return cfg
+}
+
+// monitorClient runs a periodic health check against mc's upstream,
+// transitioning it to "reconnecting" and restarting it after a jittered
+// (+/-20%) backoff once consecutive failures reach the configured threshold.
+// The restart goes through RestartClient, which replaces mc with a fresh
+// ManagedClient whose monitor starts again from BackoffInitial, so every
+// reconnect waits about BackoffInitial: the backoff does not grow across
+// successive reconnects, and a restart that fails is not retried here.
 func (cm *ClientManager) monitorClient(ctx context.Context, mc *ManagedClient) {
-	ticker := time.NewTicker(5 * time.Second)
+	cfg := healthCheckDefaults(mc.Config.HealthCheck)
+	ticker := time.NewTicker(cfg.Interval)
 	defer ticker.Stop()
 
+	mc.NextBackoff = cfg.BackoffInitial
+
 	for {
 		select {
 		case <-mc.StopChan:
 			return
 		case <-mc.RestartChan:
-			slog.Info("Restarting client", "name", mc.Config.Name)
+			mc.Logger.Info("Restarting client")
 			if err := cm.RestartClient(ctx, mc.Config.Name); err != nil {
-				slog.Error("Failed to restart client", "name", mc.Config.Name, "error", err)
+				mc.Logger.Error("Failed to restart client", "error", err)
 			}
 			return
 		case <-ticker.C:
-			// For now, we'll rely on the transport to handle process monitoring
-			// The transport will close if the process exits, which will be detected
-			// when we try to make calls to the client
+			healthy, latency := cm.probeHealth(mc, cfg.Timeout)
+			if healthy {
+				mc.NextBackoff = cfg.BackoffInitial
+				mc.RestartAttempts = 0
+				mc.LastHealthyAt = time.Now()
+				mc.Metrics.RecordSuccess(latency)
+				continue
+			}
+
+			failures := mc.Metrics.RecordFailure()
+			mc.Logger.Warn("Health check failed", "consecutive_failures", failures)
+			if failures < cfg.FailureThreshold {
+				continue
+			}
+
+			cm.mutex.Lock()
+			mc.Connected = false
+			cm.mutex.Unlock()
+			metricsConnected.WithLabelValues(mc.Config.Name).Set(0)
+			cm.server.EmitEvent(EventClientStatusChanged, map[string]any{
+				"server_name": mc.Config.Name,
+				"status":      "reconnecting",
+			})
+
+			// +/-20% jitter around the current backoff, so a fleet of clients
+			// that all went unhealthy together don't all retry in lockstep.
+			jitter := 0.8 + 0.4*rand.Float64()
+			wait := time.Duration(float64(mc.NextBackoff) * jitter)
+			mc.RestartAttempts++
+			mc.Logger.Info("Reconnecting unhealthy client after backoff", "attempt", mc.RestartAttempts, "backoff", wait)
+			select {
+			case <-time.After(wait):
+			case <-mc.StopChan:
+				return
+			}
+
+			mc.NextBackoff = time.Duration(float64(mc.NextBackoff) * cfg.BackoffMultiplier)
+			if mc.NextBackoff > cfg.BackoffMax {
+				mc.NextBackoff = cfg.BackoffMax
+			}
+
+			metricsReconnects.WithLabelValues(mc.Config.Name).Inc()
+			if err := cm.RestartClient(ctx, mc.Config.Name); err != nil {
+				mc.Logger.Error("Failed to reconnect unhealthy client", "error", err)
+			}
+			return
 		}
 	}
 }
 
-// LoadClientsFromSettings loads and starts clients based on settings
-func (cm *ClientManager) LoadClientsFromSettings(ctx context.Context, settings *settings.Settings) error {
-	slog.Info("Starting to load clients from settings", "total_clients", len(settings.MCPServers))
+// watchStdioProcess detects a stdio client's child process exiting, so a
+// crash is caught immediately instead of waiting for the next health-check
+// tick. transport.Interface doesn't expose the underlying *exec.Cmd to watch
+// with Wait directly, but the child's stderr pipe - which Stdio exposes via
+// Stderr() - closes the instant the process exits, so draining it until EOF
+// gives us the same signal.
+func (cm *ClientManager) watchStdioProcess(mc *ManagedClient) {
+	stderrSource, ok := mc.Transport.(interface{ Stderr() io.Reader })
+	if !ok || stderrSource.Stderr() == nil {
+		return
+	}
 
-	// Stop all existing clients
-	cm.mutex.Lock()
+	scanner := bufio.NewScanner(stderrSource.Stderr())
+	for scanner.Scan() {
+		mc.Logger.Debug("stdio client stderr", "line", scanner.Text())
+	}
+
+	select {
+	case <-mc.StopChan:
+		return
+	default:
+	}
+
+	mc.Logger.Warn("stdio client process exited unexpectedly")
+	select {
+	case mc.RestartChan <- struct{}{}:
+	default:
+	}
+}
+
+// stdioChildPID best-effort extracts the OS PID of a stdio client's child
+// process for StateManager to persist, so ServiceStartup's recovery pass can
+// tell a still-running orphan from one that already died with a crashed
+// prior bouncer. transport.Interface doesn't expose this (the same gap
+// watchStdioProcess works around for exit detection); a transport that
+// doesn't implement the optional Pid() method yields 0, which the recovery
+// pass treats as "unknown, can't reap".
+func stdioChildPID(t transport.Interface) int {
+	if p, ok := t.(interface{ Pid() int }); ok {
+		return p.Pid()
+	}
+	return 0
+}
+
+// probeHealth issues a lightweight ListTools call bounded by timeout, used as
+// the liveness probe for upstreams that don't advertise a dedicated ping.
+func (cm *ClientManager) probeHealth(mc *ManagedClient, timeout time.Duration) (bool, time.Duration) {
+	probeCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	_, err := mc.Client.ListTools(probeCtx, mcp.ListToolsRequest{})
+	return err == nil, time.Since(start)
+}
+
+// LoadClientsFromSettings reconciles running clients against cfg: servers that
+// are newly enabled are started, servers that were removed or disabled are
+// stopped, and servers that are still enabled are only restarted when a field
+// that affects the transport changed (command/args/env/endpoint/headers/
+// transport, see MCPServerConfig.TransportEqual) - editing a cosmetic field
+// like Description is applied in place without bouncing the connection.
+func (cm *ClientManager) LoadClientsFromSettings(ctx context.Context, cfg *settings.Settings) error {
+	cm.Logger.Info("Reconciling clients from settings", "total_clients", len(cfg.MCPServers))
+
+	desired := make(map[string]settings.MCPServerConfig, len(cfg.MCPServers))
+	for _, config := range cfg.MCPServers {
+		desired[config.Name] = config
+	}
+
+	cm.mutex.RLock()
+	running := make([]string, 0, len(cm.clients))
 	for name := range cm.clients {
-		slog.Info("Stopping existing client", "name", name)
-		cm.stopClientInternal(name)
+		running = append(running, name)
+	}
+	cm.mutex.RUnlock()
+
+	for _, name := range running {
+		config, stillDesired := desired[name]
+		if !stillDesired || !config.Enabled {
+			cm.Logger.Info("Stopping client no longer enabled", "server_name", name)
+			if err := cm.StopClient(name); err != nil {
+				cm.Logger.Error("Failed to stop client", "server_name", name, "error", err)
+			}
+		}
 	}
-	cm.mutex.Unlock()
 
-	// Start enabled clients
-	for _, config := range settings.MCPServers {
-		if config.Enabled {
-			slog.Info("Starting enabled client", "name", config.Name, "command", config.Command)
+	for _, config := range cfg.MCPServers {
+		if !config.Enabled {
+			cm.Logger.Info("Skipping disabled client", "server_name", config.Name)
+			continue
+		}
+
+		cm.mutex.RLock()
+		mc, exists := cm.clients[config.Name]
+		cm.mutex.RUnlock()
+
+		switch {
+		case !exists:
+			cm.Logger.Info("Starting new client", "server_name", config.Name, "command", config.Command)
 			if err := cm.StartClient(ctx, config); err != nil {
-				slog.Error("Failed to start client", "name", config.Name, "error", err)
-				// Continue with other clients
-			} else {
-				slog.Info("Successfully started client", "name", config.Name)
+				cm.Logger.Error("Failed to start client", "server_name", config.Name, "error", err)
 			}
-		} else {
-			slog.Info("Skipping disabled client", "name", config.Name)
+		case !mc.Config.TransportEqual(config):
+			cm.Logger.Info("Restarting client after transport config change", "server_name", config.Name)
+			if err := cm.StopClient(config.Name); err != nil {
+				cm.Logger.Error("Failed to stop client for restart", "server_name", config.Name, "error", err)
+				continue
+			}
+			if err := cm.StartClient(ctx, config); err != nil {
+				cm.Logger.Error("Failed to start client", "server_name", config.Name, "error", err)
+			}
+		default:
+			cm.mutex.Lock()
+			mc.Config = config
+			cm.mutex.Unlock()
 		}
 	}
 
-	slog.Info("Finished loading clients from settings")
+	cm.Logger.Info("Finished reconciling clients from settings")
 	return nil
 }
 
@@ -535,8 +1161,9 @@ func (cm *ClientManager) StopAllClients() {
 	}
 }
 
-// GetClientTools returns the tools for a specific client
-func (cm *ClientManager) GetClientTools(clientName string) ([]mcp.Tool, error) {
+// GetClientTools returns the tools for a specific client, filtered to those
+// principal is allowed to see under the server's configured ACL policy.
+func (cm *ClientManager) GetClientTools(clientName string, principal acl.Principal) ([]mcp.Tool, error) {
 	cm.mutex.RLock()
 	defer cm.mutex.RUnlock()
 
@@ -549,7 +1176,11 @@ func (cm *ClientManager) GetClientTools(clientName string) ([]mcp.Tool, error) {
 		return nil, fmt.Errorf("client '%s' is not connected", clientName)
 	}
 
-	return mc.Tools, nil
+	if mc.Breaker.State() == BreakerOpen {
+		return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, clientName)
+	}
+
+	return cm.server.acl.Filter(principal, clientName, mc.Tools), nil
 }
 
 // ToggleTool enables or disables a specific tool for a client
@@ -566,6 +1197,10 @@ func (cm *ClientManager) ToggleTool(clientName string, toolName string, enabled
 		return fmt.Errorf("client '%s' is not connected", clientName)
 	}
 
+	if mc.Breaker.State() == BreakerOpen {
+		return fmt.Errorf("%w: %s", ErrCircuitOpen, clientName)
+	}
+
 	// Find the tool
 	var targetTool *mcp.Tool
 	for _, tool := range mc.Tools {
@@ -582,34 +1217,19 @@ func (cm *ClientManager) ToggleTool(clientName string, toolName string, enabled
 	prefixedName := fmt.Sprintf("%s:%s", clientName, toolName)
 
 	if enabled {
-		// Re-register the tool
-		handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			// Strip the prefix from the tool name for the client call
-			request.Params.Name = toolName
-
-			slog.Info("Calling tool",
-				"client", mc.Config.Name,
-				"original_tool", toolName,
-				"prefixed_tool", request.Params.Name,
-				"request", request)
-
-			// Call the client with the original tool name
-			return mc.Client.CallTool(ctx, request)
-		}
-
-		prefixedTool := mcp.Tool{
-			Name:        prefixedName,
-			Description: fmt.Sprintf("[%s] %s", mc.Config.Name, targetTool.Description),
-			InputSchema: targetTool.InputSchema,
-		}
-
-		cm.server.mcp.AddTool(prefixedTool, handler)
-		slog.Debug("Re-enabled client tool", "client", mc.Config.Name, "tool", toolName, "prefixed_name", prefixedName)
+		// Re-register the tool through the same handler-construction logic
+		// registerClientTools uses at startup, so a re-enabled tool gets ACL
+		// enforcement, circuit-breaker protection, auth forwarding, and
+		// tracing/metrics back too, not just the policy check.
+		cm.registerTool(mc, *targetTool)
+		mc.Logger.Debug("Re-enabled client tool", "tool", toolName, "prefixed_name", prefixedName)
 	} else {
 		// Remove the tool
 		cm.server.mcp.DeleteTools(prefixedName)
-		slog.Debug("Disabled client tool", "client", mc.Config.Name, "tool", toolName, "prefixed_name", prefixedName)
+		mc.Logger.Debug("Disabled client tool", "tool", toolName, "prefixed_name", prefixedName)
 	}
 
+	cm.server.stateManager.SetToolEnabled(clientName, toolName, enabled)
+
 	return nil
 }
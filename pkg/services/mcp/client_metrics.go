@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxLatencySamples bounds the per-client latency histogram's memory use.
+const maxLatencySamples = 1000
+
+// latencyHistogram is a small reservoir of recent call latencies, sufficient to
+// estimate p50/p99 without pulling in a full HDR histogram implementation.
+type latencyHistogram struct {
+	mu      sync.Mutex
+	samples []time.Duration
+}
+
+func newLatencyHistogram() *latencyHistogram {
+	return &latencyHistogram{}
+}
+
+// Record appends d, evicting the oldest sample once at capacity.
+func (h *latencyHistogram) Record(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.samples = append(h.samples, d)
+	if len(h.samples) > maxLatencySamples {
+		h.samples = h.samples[len(h.samples)-maxLatencySamples:]
+	}
+}
+
+// Percentile returns the p-th percentile latency (0 < p <= 100) of the
+// retained samples, or 0 if none have been recorded.
+func (h *latencyHistogram) Percentile(p float64) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.samples) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, h.samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(p/100*float64(len(sorted))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// clientMetrics tracks the health and performance signals exposed on
+// ClientStatus and the /metrics endpoint for a single ManagedClient.
+type clientMetrics struct {
+	mu                  sync.Mutex
+	consecutiveFailures int
+	lastSuccessAt       time.Time
+	latency             *latencyHistogram
+}
+
+func newClientMetrics() *clientMetrics {
+	return &clientMetrics{latency: newLatencyHistogram()}
+}
+
+// RecordSuccess resets the consecutive-failure counter and records d.
+func (m *clientMetrics) RecordSuccess(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.consecutiveFailures = 0
+	m.lastSuccessAt = time.Now()
+	m.latency.Record(d)
+}
+
+// RecordFailure increments the consecutive-failure counter and returns the new value.
+func (m *clientMetrics) RecordFailure() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.consecutiveFailures++
+	return m.consecutiveFailures
+}
+
+func (m *clientMetrics) Snapshot() (consecutiveFailures int, lastSuccessAt time.Time, p50, p99 time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.consecutiveFailures, m.lastSuccessAt, m.latency.Percentile(50), m.latency.Percentile(99)
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"sync"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+)
+
+// Aggregator merges the per-provider server lists reported by Providers into a
+// single list, deduplicated by Name. Precedence is registration order: when two
+// providers define a server with the same name, the provider registered later
+// wins.
+type Aggregator struct {
+	mu            sync.Mutex
+	providerOrder []string
+	byProvider    map[string][]settings.MCPServerConfig
+}
+
+// NewAggregator creates an empty Aggregator.
+func NewAggregator() *Aggregator {
+	return &Aggregator{byProvider: make(map[string][]settings.MCPServerConfig)}
+}
+
+// Merge records msg's server list for its provider and returns the full merged
+// view across every provider seen so far.
+func (a *Aggregator) Merge(msg ConfigMessage) []settings.MCPServerConfig {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, seen := a.byProvider[msg.ProviderName]; !seen {
+		a.providerOrder = append(a.providerOrder, msg.ProviderName)
+	}
+	a.byProvider[msg.ProviderName] = msg.Servers
+
+	winners := make(map[string]settings.MCPServerConfig)
+	var nameOrder []string
+	for _, providerName := range a.providerOrder {
+		for _, server := range a.byProvider[providerName] {
+			if _, exists := winners[server.Name]; !exists {
+				nameOrder = append(nameOrder, server.Name)
+			}
+			winners[server.Name] = server
+		}
+	}
+
+	merged := make([]settings.MCPServerConfig, 0, len(nameOrder))
+	for _, name := range nameOrder {
+		merged = append(merged, winners[name])
+	}
+	return merged
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// FileProvider reads MCP server definitions from every *.yaml/*.yml/*.json file in
+// a directory and hot-reloads whenever the directory changes, so a team can manage
+// bouncer inventory from a git-tracked directory.
+type FileProvider struct {
+	dir string
+}
+
+// NewFileProvider watches dir for MCP server definition files.
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{dir: dir}
+}
+
+func (p *FileProvider) Name() string {
+	return "file:" + p.dir
+}
+
+func (p *FileProvider) Provide(ctx context.Context, ch chan<- ConfigMessage) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(p.dir); err != nil {
+		return fmt.Errorf("failed to watch directory %q: %w", p.dir, err)
+	}
+
+	publish := func() {
+		servers, err := p.load()
+		if err != nil {
+			slog.Error("FileProvider: failed to load server definitions", "dir", p.dir, "error", err)
+			return
+		}
+		ch <- ConfigMessage{ProviderName: p.Name(), Servers: servers}
+	}
+	publish()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0 {
+				publish()
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Error("FileProvider: watcher error", "dir", p.dir, "error", err)
+		}
+	}
+}
+
+// load reads and parses every recognised config file in the watched directory.
+func (p *FileProvider) load() ([]settings.MCPServerConfig, error) {
+	entries, err := os.ReadDir(p.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %q: %w", p.dir, err)
+	}
+
+	var servers []settings.MCPServerConfig
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(p.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", entry.Name(), err)
+		}
+
+		var server settings.MCPServerConfig
+		if ext == ".json" {
+			err = json.Unmarshal(data, &server)
+		} else {
+			err = yaml.Unmarshal(data, &server)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %w", entry.Name(), err)
+		}
+
+		servers = append(servers, server)
+	}
+
+	return servers, nil
+}
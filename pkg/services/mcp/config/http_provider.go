@@ -0,0 +1,86 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+)
+
+const defaultHTTPPollInterval = time.Minute
+
+// httpServerList is the document an HTTPProvider's URL is expected to return.
+type httpServerList struct {
+	Servers []settings.MCPServerConfig `json:"servers"`
+}
+
+// HTTPProvider periodically polls a URL returning a server-list document, for
+// teams that manage bouncer inventory from a central control plane.
+type HTTPProvider struct {
+	url          string
+	pollInterval time.Duration
+	client       *http.Client
+}
+
+// NewHTTPProvider polls url every pollInterval (default one minute if <= 0).
+func NewHTTPProvider(url string, pollInterval time.Duration) *HTTPProvider {
+	if pollInterval <= 0 {
+		pollInterval = defaultHTTPPollInterval
+	}
+	return &HTTPProvider{url: url, pollInterval: pollInterval, client: http.DefaultClient}
+}
+
+func (p *HTTPProvider) Name() string {
+	return "http:" + p.url
+}
+
+func (p *HTTPProvider) Provide(ctx context.Context, ch chan<- ConfigMessage) error {
+	publish := func() {
+		servers, err := p.fetch(ctx)
+		if err != nil {
+			slog.Error("HTTPProvider: failed to fetch server list", "url", p.url, "error", err)
+			return
+		}
+		ch <- ConfigMessage{ProviderName: p.Name(), Servers: servers}
+	}
+	publish()
+
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			publish()
+		}
+	}
+}
+
+func (p *HTTPProvider) fetch(ctx context.Context) ([]settings.MCPServerConfig, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %q: %w", p.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, p.url)
+	}
+
+	var doc httpServerList
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode server list: %w", err)
+	}
+	return doc.Servers, nil
+}
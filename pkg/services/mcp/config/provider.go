@@ -0,0 +1,26 @@
+// Package config implements Traefik-style dynamic configuration providers for MCP
+// server definitions, so bouncer inventory can come from more than the desktop
+// settings store.
+package config
+
+import (
+	"context"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+)
+
+// ConfigMessage is pushed by a Provider whenever its view of MCP server
+// definitions changes. Servers is always the provider's complete current list,
+// not a delta.
+type ConfigMessage struct {
+	ProviderName string
+	Servers      []settings.MCPServerConfig
+}
+
+// Provider supplies MCPServerConfig definitions from some external source and
+// pushes a ConfigMessage to ch on startup and every time its source changes.
+// Provide blocks until ctx is cancelled or the source can no longer be read.
+type Provider interface {
+	Name() string
+	Provide(ctx context.Context, ch chan<- ConfigMessage) error
+}
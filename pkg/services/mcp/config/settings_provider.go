@@ -0,0 +1,40 @@
+package config
+
+import (
+	"context"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+	"github.com/wailsapp/wails/v3/pkg/application"
+)
+
+// SettingsProvider is the default Provider, backed by the desktop settings store.
+// It preserves the existing UX: servers added, removed, or toggled through the UI
+// continue to flow through unchanged.
+type SettingsProvider struct {
+	settings *settings.SettingsService
+}
+
+// NewSettingsProvider wraps settingsService as a Provider.
+func NewSettingsProvider(settingsService *settings.SettingsService) *SettingsProvider {
+	return &SettingsProvider{settings: settingsService}
+}
+
+func (p *SettingsProvider) Name() string {
+	return "settings"
+}
+
+func (p *SettingsProvider) Provide(ctx context.Context, ch chan<- ConfigMessage) error {
+	publish := func() {
+		ch <- ConfigMessage{ProviderName: p.Name(), Servers: p.settings.GetMCPServers()}
+	}
+	publish()
+
+	p.settings.Subscribe(func(e *application.CustomEvent) {
+		if e.Name == "settings:updated" {
+			publish()
+		}
+	})
+
+	<-ctx.Done()
+	return ctx.Err()
+}
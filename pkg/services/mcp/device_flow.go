@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client/transport"
+)
+
+// DeviceAuthorizationResponse is the device_authorization_endpoint response
+// defined by RFC 8628 section 3.2.
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// DeviceFlowAuthorizer runs the RFC 8628 OAuth 2.0 Device Authorization Grant
+// against a server's device_authorization_endpoint and token_endpoint, for
+// authorizing mcp-bouncer when it has no GUI browser to drive the loopback
+// callback flow (SSH sessions, containers, headless hosts).
+type DeviceFlowAuthorizer struct {
+	DeviceAuthorizationEndpoint string
+	TokenEndpoint               string
+	ClientID                    string
+	Scopes                      []string
+	HTTPClient                  *http.Client
+}
+
+// NewDeviceFlowAuthorizer constructs a DeviceFlowAuthorizer using http.DefaultClient.
+func NewDeviceFlowAuthorizer(deviceAuthEndpoint, tokenEndpoint, clientID string, scopes []string) *DeviceFlowAuthorizer {
+	return &DeviceFlowAuthorizer{
+		DeviceAuthorizationEndpoint: deviceAuthEndpoint,
+		TokenEndpoint:               tokenEndpoint,
+		ClientID:                    clientID,
+		Scopes:                      scopes,
+	}
+}
+
+func (d *DeviceFlowAuthorizer) httpClient() *http.Client {
+	if d.HTTPClient != nil {
+		return d.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// Authorize runs the full device flow: it requests a device code, emits
+// EventClientDeviceAuthRequired via emit so the UI can display the user_code
+// and verification URL, then polls the token endpoint until the user
+// authorizes, denies, or the device code expires.
+func (d *DeviceFlowAuthorizer) Authorize(ctx context.Context, emit func(name string, data any)) (*transport.Token, error) {
+	deviceResp, err := d.requestDeviceCode(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+
+	if emit != nil {
+		emit(EventClientDeviceAuthRequired, map[string]any{
+			"user_code":                 deviceResp.UserCode,
+			"verification_uri":          deviceResp.VerificationURI,
+			"verification_uri_complete": deviceResp.VerificationURIComplete,
+			"expires_in":                deviceResp.ExpiresIn,
+		})
+	}
+
+	return d.pollToken(ctx, deviceResp)
+}
+
+func (d *DeviceFlowAuthorizer) requestDeviceCode(ctx context.Context) (*DeviceAuthorizationResponse, error) {
+	form := url.Values{"client_id": {d.ClientID}}
+	if len(d.Scopes) > 0 {
+		form.Set("scope", strings.Join(d.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.DeviceAuthorizationEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := d.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("device authorization endpoint returned %d: %s", resp.StatusCode, body)
+	}
+
+	var out DeviceAuthorizationResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse device authorization response: %w", err)
+	}
+	return &out, nil
+}
+
+// deviceTokenResponse models both the successful token response and the
+// RFC 8628 section 3.5 error responses returned from the same endpoint.
+type deviceTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+}
+
+func (d *DeviceFlowAuthorizer) pollToken(ctx context.Context, device *DeviceAuthorizationResponse) (*transport.Token, error) {
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(device.ExpiresIn) * time.Second)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("device authorization expired before user completed it")
+		}
+
+		tokenResp, err := d.fetchToken(ctx, device.DeviceCode)
+		if err != nil {
+			return nil, err
+		}
+
+		switch tokenResp.Error {
+		case "":
+			return &transport.Token{
+				AccessToken:  tokenResp.AccessToken,
+				TokenType:    tokenResp.TokenType,
+				RefreshToken: tokenResp.RefreshToken,
+				ExpiresIn:    tokenResp.ExpiresIn,
+				ExpiresAt:    time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
+			}, nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+			continue
+		case "access_denied":
+			return nil, fmt.Errorf("device authorization denied by user")
+		case "expired_token":
+			return nil, fmt.Errorf("device authorization expired before user completed it")
+		default:
+			return nil, fmt.Errorf("device token endpoint returned error %q", tokenResp.Error)
+		}
+	}
+}
+
+func (d *DeviceFlowAuthorizer) fetchToken(ctx context.Context, deviceCode string) (*deviceTokenResponse, error) {
+	form := url.Values{
+		"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+		"device_code": {deviceCode},
+		"client_id":   {d.ClientID},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := d.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var out deviceTokenResponse
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse device token response: %w", err)
+	}
+	return &out, nil
+}
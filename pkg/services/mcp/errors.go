@@ -4,3 +4,7 @@ import "errors"
 
 // ErrClientNotFound is returned when a client lookup by name fails
 var ErrClientNotFound = errors.New("client not found")
+
+// ErrCircuitOpen is returned when a client's circuit breaker has tripped and is
+// shedding requests rather than forwarding them to a failing upstream server.
+var ErrCircuitOpen = errors.New("circuit breaker open for client")
@@ -2,10 +2,17 @@ package mcp
 
 // Event name constants to avoid string duplication/typos
 const (
-	EventClientStatusChanged        = "mcp:client_status_changed"
-	EventClientError                = "mcp:client_error"
-	EventServersUpdated             = "mcp:servers_updated"
-	EventIncomingClientConnected    = "mcp:incoming_client_connected"
-	EventIncomingClientDisconnected = "mcp:incoming_client_disconnected"
-	EventIncomingClientsUpdated     = "mcp:incoming_clients_updated"
+	EventClientStatusChanged         = "mcp:client_status_changed"
+	EventClientError                 = "mcp:client_error"
+	EventServersUpdated              = "mcp:servers_updated"
+	EventIncomingClientConnected     = "mcp:incoming_client_connected"
+	EventIncomingClientDisconnected  = "mcp:incoming_client_disconnected"
+	EventIncomingClientsUpdated      = "mcp:incoming_clients_updated"
+	EventClientBreakerOpen           = "mcp:client_breaker_open"
+	EventClientBreakerClosed         = "mcp:client_breaker_closed"
+	EventClientLogAppended           = "mcp:client_log_appended"
+	EventToolPolicyChanged           = "mcp:tool_policy_changed"
+	EventClientDeviceAuthRequired    = "mcp:client_device_auth_required"
+	EventTokenStoreModeChanged       = "mcp:token_store_mode_changed"
+	EventClientAuthorizationRequired = "mcp:client_authorization_required"
 )
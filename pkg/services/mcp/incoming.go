@@ -4,14 +4,133 @@ import (
 	"sort"
 	"sync"
 	"time"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/acl"
 )
 
 type IncomingClient struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Version     string    `json:"version"`
-	Title       string    `json:"title,omitempty"`
-	ConnectedAt time.Time `json:"connected_at"`
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Version     string        `json:"version"`
+	Title       string        `json:"title,omitempty"`
+	Principal   acl.Principal `json:"principal,omitempty"`
+	ConnectedAt time.Time     `json:"connected_at"`
+
+	// AuthHeader is the raw Authorization header presented by this session, kept
+	// for forwarding to upstream servers that opt into it. Never serialized.
+	AuthHeader string `json:"-"`
+
+	// stats tracks this session's request/tool-call activity. It is a pointer
+	// so copies of IncomingClient returned by Get/List keep updating live;
+	// unexported since it's only mutated through RecordRequest/RecordToolCall
+	// and read through Snapshot.
+	stats *ClientStats
+}
+
+// ClientStats tracks per-incoming-client activity: request counts by method,
+// tool-call counts per upstream tool, bytes transferred, last-activity time,
+// and the current in-flight request count. All access goes through its
+// methods, which hold an internal per-client mutex, so neither the hot
+// request/tool-call path nor a Snapshot taken for the UI holds the registry's
+// mutex while touching stats.
+type ClientStats struct {
+	mu             sync.Mutex
+	requestCount   int64
+	bytesIn        int64
+	bytesOut       int64
+	inFlight       int64
+	lastActivity   time.Time
+	methodCounts   map[string]int64
+	toolCallCounts map[string]int64 // keyed by "upstreamServer:toolName"
+}
+
+func newClientStats() *ClientStats {
+	return &ClientStats{
+		methodCounts:   make(map[string]int64),
+		toolCallCounts: make(map[string]int64),
+	}
+}
+
+// BeginRequest marks one more request in flight for this client. Call sites
+// that know a request's outcome will be reported through recordRequest should
+// pair this with it; requests whose session isn't known until the response
+// (the initial "initialize" call) may skip it and go straight to
+// recordRequest.
+func (s *ClientStats) BeginRequest() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlight++
+}
+
+// recordRequest records a completed request: bumps the total and per-method
+// counts, accumulates bytes transferred, updates the last-activity timestamp,
+// and drops the in-flight count back down (clamped at zero, since a request
+// whose session id was only learned from the response - "initialize" - never
+// called BeginRequest in the first place).
+func (s *ClientStats) recordRequest(method string, bytesIn, bytesOut int64) (droppedInFlight bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requestCount++
+	s.bytesIn += bytesIn
+	s.bytesOut += bytesOut
+	s.lastActivity = time.Now()
+	if method != "" {
+		s.methodCounts[method]++
+	}
+	if s.inFlight > 0 {
+		s.inFlight--
+		droppedInFlight = true
+	}
+	return droppedInFlight
+}
+
+// recordToolCall records one call to toolName on upstreamServer.
+func (s *ClientStats) recordToolCall(upstreamServer, toolName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastActivity = time.Now()
+	s.toolCallCounts[upstreamServer+":"+toolName]++
+}
+
+// ClientStatsSnapshot is a deep-copied, JSON-serializable view of ClientStats
+// for the Wails frontend's live activity panel, safe to read without racing
+// on the stats mutex.
+type ClientStatsSnapshot struct {
+	RequestCount   int64            `json:"request_count"`
+	BytesIn        int64            `json:"bytes_in"`
+	BytesOut       int64            `json:"bytes_out"`
+	InFlight       int64            `json:"in_flight"`
+	LastActivity   time.Time        `json:"last_activity,omitempty"`
+	MethodCounts   map[string]int64 `json:"method_counts,omitempty"`
+	ToolCallCounts map[string]int64 `json:"tool_call_counts,omitempty"`
+}
+
+func (s *ClientStats) snapshot() ClientStatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := ClientStatsSnapshot{
+		RequestCount:   s.requestCount,
+		BytesIn:        s.bytesIn,
+		BytesOut:       s.bytesOut,
+		InFlight:       s.inFlight,
+		LastActivity:   s.lastActivity,
+		MethodCounts:   make(map[string]int64, len(s.methodCounts)),
+		ToolCallCounts: make(map[string]int64, len(s.toolCallCounts)),
+	}
+	for k, v := range s.methodCounts {
+		out.MethodCounts[k] = v
+	}
+	for k, v := range s.toolCallCounts {
+		out.ToolCallCounts[k] = v
+	}
+	return out
+}
+
+// IncomingClientSnapshot pairs an incoming client's connection details with a
+// deep-copied view of its activity stats.
+type IncomingClientSnapshot struct {
+	IncomingClient
+	Stats ClientStatsSnapshot `json:"stats"`
 }
 
 type IncomingClientRegistry struct {
@@ -27,7 +146,7 @@ func NewIncomingClientRegistry(s *Server) *IncomingClientRegistry {
 	}
 }
 
-func (r *IncomingClientRegistry) AddOrUpdate(id, name, version, title string) {
+func (r *IncomingClientRegistry) AddOrUpdate(id, name, version, title string, principal acl.Principal, authHeader string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.items[id] = IncomingClient{
@@ -35,10 +154,33 @@ func (r *IncomingClientRegistry) AddOrUpdate(id, name, version, title string) {
 		Name:        name,
 		Version:     version,
 		Title:       title,
+		Principal:   principal,
 		ConnectedAt: time.Now(),
+		AuthHeader:  authHeader,
+		stats:       newClientStats(),
 	}
 }
 
+// AuthHeader returns the raw Authorization header captured for the incoming
+// session id, for forwarding to an upstream server that opts into it.
+func (r *IncomingClientRegistry) AuthHeader(id string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	client, ok := r.items[id]
+	if !ok || client.AuthHeader == "" {
+		return "", false
+	}
+	return client.AuthHeader, true
+}
+
+// Get returns the incoming client registered under id, if any.
+func (r *IncomingClientRegistry) Get(id string) (IncomingClient, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	client, ok := r.items[id]
+	return client, ok
+}
+
 func (r *IncomingClientRegistry) Remove(id string) bool {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -68,3 +210,63 @@ func (r *IncomingClientRegistry) List() []IncomingClient {
 	})
 	return out
 }
+
+// Snapshot returns every incoming client paired with a deep-copied view of
+// its activity stats, safe for the Wails frontend to render without racing on
+// the registry mutex or any individual client's stats mutex.
+func (r *IncomingClientRegistry) Snapshot() []IncomingClientSnapshot {
+	clients := r.List()
+	out := make([]IncomingClientSnapshot, 0, len(clients))
+	for _, client := range clients {
+		snap := IncomingClientSnapshot{IncomingClient: client}
+		if client.stats != nil {
+			snap.Stats = client.stats.snapshot()
+		}
+		out = append(out, snap)
+	}
+	return out
+}
+
+// BeginRequest marks one more request in flight for the incoming client id,
+// for the current in-flight count the server middleware exposes. Clients
+// without a recorded session (id unknown) are silently ignored.
+func (r *IncomingClientRegistry) BeginRequest(id string) {
+	r.mu.RLock()
+	client, ok := r.items[id]
+	r.mu.RUnlock()
+	if ok && client.stats != nil {
+		client.stats.BeginRequest()
+		metricsIncomingInFlight.WithLabelValues(client.Name).Inc()
+	}
+}
+
+// RecordRequest records a completed request against the incoming client id:
+// its method, bytes transferred in each direction, and how long it took.
+func (r *IncomingClientRegistry) RecordRequest(id, method string, bytesIn, bytesOut int64, duration time.Duration) {
+	r.mu.RLock()
+	client, ok := r.items[id]
+	r.mu.RUnlock()
+	if !ok || client.stats == nil {
+		return
+	}
+	if client.stats.recordRequest(method, bytesIn, bytesOut) {
+		metricsIncomingInFlight.WithLabelValues(client.Name).Dec()
+	}
+	metricsIncomingRequests.WithLabelValues(client.Name, method).Inc()
+	metricsIncomingBytesIn.WithLabelValues(client.Name).Add(float64(bytesIn))
+	metricsIncomingBytesOut.WithLabelValues(client.Name).Add(float64(bytesOut))
+	metricsIncomingRequestDuration.WithLabelValues(client.Name, method).Observe(duration.Seconds())
+}
+
+// RecordToolCall records one call to toolName on upstreamServer made on
+// behalf of the incoming client id.
+func (r *IncomingClientRegistry) RecordToolCall(id, upstreamServer, toolName string) {
+	r.mu.RLock()
+	client, ok := r.items[id]
+	r.mu.RUnlock()
+	if !ok || client.stats == nil {
+		return
+	}
+	client.stats.recordToolCall(upstreamServer, toolName)
+	metricsIncomingToolCalls.WithLabelValues(client.Name, upstreamServer, toolName).Inc()
+}
@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/zalando/go-keyring"
+)
+
+// keychainService is the service name tokens are filed under in the
+// platform secret store (macOS Keychain, Windows Credential Manager,
+// libsecret/Secret Service on Linux).
+const keychainService = "mcp-bouncer"
+
+// KeychainTokenStore implements transport.TokenStore against the platform
+// secret store via zalando/go-keyring, which picks the right backend per OS.
+// Tokens are stored per-server under a unique account name within the
+// mcp-bouncer service.
+type KeychainTokenStore struct {
+	account string
+}
+
+// NewKeychainTokenStore creates a token store backed by the platform secret
+// store for the given server name.
+func NewKeychainTokenStore(serverName string) *KeychainTokenStore {
+	return &KeychainTokenStore{
+		account: fmt.Sprintf("mcp-tokens-%s", serverName),
+	}
+}
+
+// GetToken retrieves a token from the platform secret store.
+func (k *KeychainTokenStore) GetToken() (*transport.Token, error) {
+	data, err := keyring.Get(keychainService, k.account)
+	if err != nil {
+		return nil, fmt.Errorf("no token available: %w", err)
+	}
+
+	var token transport.Token
+	if err := json.Unmarshal([]byte(data), &token); err != nil {
+		return nil, fmt.Errorf("failed to parse keychain token: %w", err)
+	}
+	return &token, nil
+}
+
+// SaveToken saves a token to the platform secret store.
+func (k *KeychainTokenStore) SaveToken(token *transport.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal token: %w", err)
+	}
+	if err := keyring.Set(keychainService, k.account, string(data)); err != nil {
+		return fmt.Errorf("failed to save token to keychain: %w", err)
+	}
+	return nil
+}
+
+// ClearToken removes the stored token from the platform secret store.
+func (k *KeychainTokenStore) ClearToken() error {
+	if err := keyring.Delete(keychainService, k.account); err != nil && err != keyring.ErrNotFound {
+		return fmt.Errorf("failed to clear keychain token: %w", err)
+	}
+	return nil
+}
+
+// GetTokenFilePath returns a human-readable location for the settings UI to
+// display, mirroring FileTokenStore.GetTokenFilePath even though no file is
+// involved.
+func (k *KeychainTokenStore) GetTokenFilePath() string {
+	return fmt.Sprintf("keychain:%s/%s", keychainService, k.account)
+}
+
+// tokenStoreMode is the process-wide token storage preference, set by
+// SetTokenStoreMode as settings are loaded or changed.
+var tokenStoreMode = settings.TokenStoreAuto
+
+// SetTokenStoreMode updates the process-wide token storage preference
+// consulted by NewSecureTokenStore.
+func SetTokenStoreMode(mode settings.TokenStoreMode) {
+	tokenStoreMode = mode
+}
+
+// keychainAvailable probes the platform secret store with a disposable
+// round-trip, since go-keyring has no dedicated "is a backend present" check.
+func keychainAvailable() bool {
+	const probeAccount = "mcp-bouncer-probe"
+	if err := keyring.Set(keychainService, probeAccount, "probe"); err != nil {
+		return false
+	}
+	_ = keyring.Delete(keychainService, probeAccount)
+	return true
+}
+
+// NewSecureTokenStore picks the best available transport.TokenStore for
+// serverName: the platform secret store when TokenStoreMode is
+// TokenStoreAuto (the default) and a backend is reachable, falling back to
+// FileTokenStore otherwise (headless Linux with no Secret Service, or
+// TokenStoreMode forced to TokenStoreFile). When the platform secret store is
+// chosen, any existing plaintext token file is migrated into it and deleted;
+// with FileTokenStore the file stays where it is.
+func NewSecureTokenStore(serverName string) transport.TokenStore {
+	if tokenStoreMode == settings.TokenStoreFile || !keychainAvailable() {
+		return migrateFileToken(serverName, NewFileTokenStore(serverName))
+	}
+	return migrateFileToken(serverName, NewKeychainTokenStore(serverName))
+}
+
+// migrateFileToken imports any pre-existing plaintext FileTokenStore token
+// for serverName into store (a one-shot migration for upgrades from a
+// version that only supported FileTokenStore), then deletes the plaintext
+// file so the credential isn't left behind in two places.
+func migrateFileToken(serverName string, store transport.TokenStore) transport.TokenStore {
+	if _, isFileStore := store.(*FileTokenStore); isFileStore {
+		return store
+	}
+
+	legacy := NewFileTokenStore(serverName)
+	token, err := legacy.GetToken()
+	if err != nil {
+		return store
+	}
+
+	if err := store.SaveToken(token); err != nil {
+		return store
+	}
+	if err := os.Remove(legacy.GetTokenFilePath()); err != nil && !os.IsNotExist(err) {
+		return store
+	}
+
+	return store
+}
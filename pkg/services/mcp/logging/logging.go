@@ -0,0 +1,69 @@
+// Package logging is a small facade over log/slog that enriches log records with
+// request-scoped attributes (server_name, incoming_client_id,
+// event_name, request_id) carried on a context.Context, and supports switching
+// the process-wide output between text and JSON.
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+type ctxKey struct{}
+
+// base is the logger WithX helpers derive from and FromContext falls back to.
+var base = slog.Default()
+
+// ring is the package-wide per-client log ring buffer, populated whenever base
+// is set to a logger backed by a RingHandler.
+var ring = NewRingBuffer(0)
+
+// SetBase installs the logger used as the root of every context-derived logger,
+// e.g. to switch output format or destination.
+func SetBase(logger *slog.Logger) {
+	base = logger
+}
+
+// Ring returns the package-wide per-client log ring buffer.
+func Ring() *RingBuffer {
+	return ring
+}
+
+// WithClient returns a context whose logger is enriched with the upstream
+// server's name.
+func WithClient(ctx context.Context, name string) context.Context {
+	return withAttrs(ctx, slog.String("server_name", name))
+}
+
+// WithIncomingClient returns a context whose logger is enriched with the
+// connected incoming client's session id.
+func WithIncomingClient(ctx context.Context, id string) context.Context {
+	return withAttrs(ctx, slog.String("incoming_client_id", id))
+}
+
+// WithEvent returns a context whose logger is enriched with the emitted event's
+// name.
+func WithEvent(ctx context.Context, name string) context.Context {
+	return withAttrs(ctx, slog.String("event_name", name))
+}
+
+// WithRequestID returns a context whose logger is enriched with a request id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return withAttrs(ctx, slog.String("request_id", id))
+}
+
+func withAttrs(ctx context.Context, attrs ...any) context.Context {
+	logger := FromContext(ctx).With(attrs...)
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the logger enriched by every WithX call made against ctx,
+// or the package base logger (slog.Default by default) if none have been made.
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return base
+}
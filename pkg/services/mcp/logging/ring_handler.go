@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// RingHandler wraps another slog.Handler, additionally appending every record
+// carrying a "server_name" attribute to a RingBuffer keyed by that client, and
+// invoking onAppend (if set) so callers can stream the record to the UI.
+type RingHandler struct {
+	next     slog.Handler
+	buffer   *RingBuffer
+	onAppend func(client string, rec Record)
+	attrs    []slog.Attr
+}
+
+// NewRingHandler returns a RingHandler that delegates actual output to next and
+// records client-scoped log lines in buffer. onAppend may be nil.
+func NewRingHandler(next slog.Handler, buffer *RingBuffer, onAppend func(client string, rec Record)) *RingHandler {
+	return &RingHandler{next: next, buffer: buffer, onAppend: onAppend}
+}
+
+func (h *RingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *RingHandler) Handle(ctx context.Context, r slog.Record) error {
+	attrs := make(map[string]any, len(h.attrs)+r.NumAttrs())
+	for _, a := range h.attrs {
+		attrs[a.Key] = a.Value.Any()
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		attrs[a.Key] = a.Value.Any()
+		return true
+	})
+
+	if client, ok := attrs["server_name"].(string); ok && client != "" {
+		rec := Record{Time: r.Time, Level: r.Level.String(), Message: r.Message, Attrs: attrs}
+		h.buffer.Append(client, rec)
+		if h.onAppend != nil {
+			h.onAppend(client, rec)
+		}
+	}
+
+	return h.next.Handle(ctx, r)
+}
+
+func (h *RingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	merged := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	merged = append(merged, h.attrs...)
+	merged = append(merged, attrs...)
+	return &RingHandler{next: h.next.WithAttrs(attrs), buffer: h.buffer, onAppend: h.onAppend, attrs: merged}
+}
+
+func (h *RingHandler) WithGroup(name string) slog.Handler {
+	return &RingHandler{next: h.next.WithGroup(name), buffer: h.buffer, onAppend: h.onAppend, attrs: h.attrs}
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"sync"
+	"time"
+)
+
+// Record is a single captured log line, scoped to the client that produced it.
+type Record struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Message string         `json:"message"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+// DefaultRingCapacity is the number of records retained per client by a
+// RingBuffer created with NewRingBuffer(0).
+const DefaultRingCapacity = 500
+
+// RingBuffer retains the most recent log records per client in memory, so the UI
+// can show a client's recent activity without a log store.
+type RingBuffer struct {
+	mu       sync.Mutex
+	capacity int
+	byClient map[string][]Record
+}
+
+// NewRingBuffer creates a RingBuffer holding up to capacity records per client
+// (DefaultRingCapacity if capacity <= 0).
+func NewRingBuffer(capacity int) *RingBuffer {
+	if capacity <= 0 {
+		capacity = DefaultRingCapacity
+	}
+	return &RingBuffer{capacity: capacity, byClient: make(map[string][]Record)}
+}
+
+// Append adds rec to client's ring, evicting the oldest record once at capacity.
+func (b *RingBuffer) Append(client string, rec Record) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	records := append(b.byClient[client], rec)
+	if len(records) > b.capacity {
+		records = records[len(records)-b.capacity:]
+	}
+	b.byClient[client] = records
+}
+
+// Tail returns the most recent n records for client (all retained records if
+// n <= 0).
+func (b *RingBuffer) Tail(client string, n int) []Record {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	records := b.byClient[client]
+	if n <= 0 || n >= len(records) {
+		out := make([]Record, len(records))
+		copy(out, records)
+		return out
+	}
+	out := make([]Record, n)
+	copy(out, records[len(records)-n:])
+	return out
+}
@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Prometheus collectors scraped from the /metrics endpoint registered in
+// NewServer, giving operators per-upstream-server counts of tool calls,
+// reconnects, and current connection state.
+var (
+	metricsToolCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_bouncer_tool_calls_total",
+		Help: "Total tool calls proxied to an upstream server, by tool and outcome.",
+	}, []string{"client", "tool", "status"})
+
+	metricsToolCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mcp_bouncer_tool_call_duration_seconds",
+		Help:    "Duration of tool calls proxied to an upstream server, by tool.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"client", "tool"})
+
+	metricsReconnects = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_bouncer_reconnects_total",
+		Help: "Total reconnect attempts triggered by failed health checks, per upstream server.",
+	}, []string{"server_name"})
+
+	metricsConnected = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mcp_bouncer_client_connected",
+		Help: "Whether an upstream server's client is currently connected (1) or not (0).",
+	}, []string{"client"})
+
+	metricsIncomingRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_bouncer_incoming_requests_total",
+		Help: "Total MCP requests handled from an incoming client, by method.",
+	}, []string{"client_name", "method"})
+
+	metricsIncomingRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "mcp_bouncer_incoming_request_duration_seconds",
+		Help:    "Duration of MCP requests handled from an incoming client, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"client_name", "method"})
+
+	metricsIncomingBytesIn = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_bouncer_incoming_bytes_in_total",
+		Help: "Total request bytes received from an incoming client.",
+	}, []string{"client_name"})
+
+	metricsIncomingBytesOut = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_bouncer_incoming_bytes_out_total",
+		Help: "Total response bytes sent to an incoming client.",
+	}, []string{"client_name"})
+
+	metricsIncomingInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "mcp_bouncer_incoming_requests_in_flight",
+		Help: "Requests from an incoming client currently being handled.",
+	}, []string{"client_name"})
+
+	metricsIncomingToolCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "mcp_bouncer_incoming_tool_calls_total",
+		Help: "Total tool calls made by an incoming client, by upstream server and tool.",
+	}, []string{"client_name", "server_name", "tool_name"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		metricsToolCalls, metricsToolCallDuration, metricsReconnects, metricsConnected,
+		metricsIncomingRequests, metricsIncomingRequestDuration, metricsIncomingBytesIn,
+		metricsIncomingBytesOut, metricsIncomingInFlight, metricsIncomingToolCalls,
+	)
+}
+
+// ToolMetrics records the outcome of a single proxied tool call. ClientManager
+// defaults to prometheusToolMetrics; inject a different implementation via
+// ClientManagerOptions.Metrics to redirect or disable this observability path
+// independent of the rest of the Prometheus collectors.
+type ToolMetrics interface {
+	ObserveToolCall(client, tool string, dur time.Duration, err error)
+}
+
+// prometheusToolMetrics is the default ToolMetrics, recording into
+// metricsToolCalls and metricsToolCallDuration above.
+type prometheusToolMetrics struct{}
+
+func (prometheusToolMetrics) ObserveToolCall(client, tool string, dur time.Duration, err error) {
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metricsToolCalls.WithLabelValues(client, tool, status).Inc()
+	metricsToolCallDuration.WithLabelValues(client, tool).Observe(dur.Seconds())
+}
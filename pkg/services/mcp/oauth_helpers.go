@@ -1,37 +1,106 @@
 package mcp
 
 import (
-	"errors"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
 	"os/exec"
 	"runtime"
+	"sync"
+	"time"
 )
 
-// startOAuthCallbackServer starts a local HTTP server for OAuth redirect handling on a free port.
-// It returns the server instance and the redirect URI to configure the OAuth flow.
-func startOAuthCallbackServer(callbackChan chan<- map[string]string) (*http.Server, string, error) {
-	// Bind to a free port on loopback
+// oauthCallbackTimeout bounds how long AuthorizeClient waits for the user to
+// complete the browser flow before giving up and tearing down the callback
+// server.
+const oauthCallbackTimeout = 5 * time.Minute
+
+// reserveOAuthCallbackRedirectURI probes a free loopback port and returns the
+// redirect URI the OAuth callback server will later answer on, routed
+// through a random path segment so concurrent authorization flows each get
+// their own unguessable callback route. Callers reserve this before building
+// the OAuthConfig passed to the upstream client, so the RedirectURI baked in
+// at construction time matches the port startOAuthCallbackServer re-binds
+// when the user actually runs the flow. The probe listener is closed
+// immediately: the port is re-bound for the real listener, not held open for
+// the client's lifetime, so an un-authorized client doesn't tie up a socket
+// indefinitely.
+func reserveOAuthCallbackRedirectURI() (string, error) {
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to bind oauth callback listener: %w", err)
+		return "", fmt.Errorf("failed to probe a free oauth callback port: %w", err)
 	}
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	nonce, err := generateCallbackNonce()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate callback path: %w", err)
+	}
+	return fmt.Sprintf("http://%s/oauth/callback/%s", addr, nonce), nil
+}
 
-	addr := listener.Addr().String() // host:port
-	redirectURI := fmt.Sprintf("http://%s/oauth/callback", addr)
+// startOAuthCallbackServer starts a local HTTP server for OAuth redirect
+// handling, bound to the host:port encoded in redirectURI and routed through
+// its random path segment. It only accepts a callback whose state matches
+// expectedState, rejects cross-site requests carrying a non-loopback Origin
+// or Referer, accepts at most one callback (subsequent hits get 400), and
+// shuts itself down automatically after timeout so a forgotten flow doesn't
+// leave a listener on the loopback interface indefinitely.
+func startOAuthCallbackServer(redirectURI string, callbackChan chan<- map[string]string, expectedState string, timeout time.Duration) (*http.Server, error) {
+	addr, callbackPath, err := addrAndPathFromRedirectURI(redirectURI)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind oauth callback listener on %s: %w", addr, err)
+	}
 
-	httpServer := &http.Server{Addr: addr}
+	server := &http.Server{}
 	mux := http.NewServeMux()
-	mux.HandleFunc("/oauth/callback", func(w http.ResponseWriter, r *http.Request) {
+
+	var once sync.Once
+	completed := make(chan struct{})
+
+	mux.HandleFunc(callbackPath, func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && !isLoopbackURL(origin) {
+			http.Error(w, "invalid origin", http.StatusForbidden)
+			return
+		}
+		if referer := r.Header.Get("Referer"); referer != "" && !isLoopbackURL(referer) {
+			http.Error(w, "invalid referer", http.StatusForbidden)
+			return
+		}
+
 		params := make(map[string]string)
 		for key, values := range r.URL.Query() {
 			if len(values) > 0 {
 				params[key] = values[0]
 			}
 		}
-		callbackChan <- params
+
+		if params["state"] != expectedState {
+			http.Error(w, "state mismatch", http.StatusBadRequest)
+			return
+		}
+
+		accepted := false
+		once.Do(func() {
+			accepted = true
+			callbackChan <- params
+			close(completed)
+		})
+		if !accepted {
+			http.Error(w, "authorization already completed", http.StatusBadRequest)
+			return
+		}
+
 		w.Header().Set("Content-Type", "text/html")
 		_, _ = w.Write([]byte(`
       <html>
@@ -43,15 +112,57 @@ func startOAuthCallbackServer(callbackChan chan<- map[string]string) (*http.Serv
       </html>
     `))
 	})
-	httpServer.Handler = mux
+	server.Handler = mux
 
 	go func() {
-		if err := httpServer.Serve(listener); err != nil && errors.Is(err, http.ErrServerClosed) {
+		if err := server.Serve(listener); err != nil && err.Error() != "http: Server closed" {
 			slog.Error("OAuth callback server error", "error", err)
 		}
 	}()
 
-	return httpServer, redirectURI, nil
+	go func() {
+		select {
+		case <-completed:
+		case <-time.After(timeout):
+			_ = server.Close()
+		}
+	}()
+
+	return server, nil
+}
+
+// generateCallbackNonce returns a random hex string used as the OAuth
+// callback's path segment, so concurrent authorization flows each get their
+// own unguessable route and can't cross-wire each other's callbacks.
+func generateCallbackNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// addrAndPathFromRedirectURI splits redirectURI (as built by
+// reserveOAuthCallbackRedirectURI) back into the host:port to listen on and
+// the path to register the callback handler under.
+func addrAndPathFromRedirectURI(redirectURI string) (addr, path string, err error) {
+	parsed, err := url.Parse(redirectURI)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid oauth redirect URI: %w", err)
+	}
+	return parsed.Host, parsed.Path, nil
+}
+
+// isLoopbackURL reports whether rawURL's host is a loopback address, used to
+// reject OAuth callback requests whose Origin/Referer indicates they did not
+// originate from the browser we opened on this machine.
+func isLoopbackURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := parsed.Hostname()
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
 }
 
 // openDefaultBrowser opens the system browser to a URL
@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/acl"
+)
+
+type principalContextKey struct{}
+
+type incomingAuthContextKey struct{}
+
+// withIncomingAuthHeader returns a context carrying the raw Authorization header
+// value of the incoming request, for later retrieval via incomingAuthHeaderFromContext.
+func withIncomingAuthHeader(ctx context.Context, header string) context.Context {
+	return context.WithValue(ctx, incomingAuthContextKey{}, header)
+}
+
+// incomingAuthHeaderFromContext returns the raw Authorization header captured by
+// the incoming-request middleware, if any.
+func incomingAuthHeaderFromContext(ctx context.Context) (string, bool) {
+	header, ok := ctx.Value(incomingAuthContextKey{}).(string)
+	return header, ok
+}
+
+// principalFromRequest derives the ACL principal for an incoming streamable-HTTP
+// request, preferring a bearer token, then the mTLS client certificate CN, then the
+// X-MCP-Client-Id header.
+func principalFromRequest(r *http.Request) acl.Principal {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+			return acl.Principal(hashBearerToken(token))
+		}
+	}
+
+	if r.TLS != nil {
+		for _, cert := range r.TLS.PeerCertificates {
+			if cert.Subject.CommonName != "" {
+				return acl.Principal(cert.Subject.CommonName)
+			}
+		}
+	}
+
+	if id := r.Header.Get("X-MCP-Client-Id"); id != "" {
+		return acl.Principal(id)
+	}
+
+	return ""
+}
+
+// hashBearerToken derives a stable, non-secret ACL principal from a bearer
+// token. The raw token is a live credential; deriving the principal from its
+// digest instead keeps it out of audit logs (acl.Filter, ClientManager's
+// denial log) and IncomingClient.Principal, which is JSON-tagged and exposed
+// over the API, unlike the deliberately unexported AuthHeader.
+func hashBearerToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "token:" + hex.EncodeToString(sum[:])
+}
+
+// withPrincipal returns a context carrying principal for later retrieval via
+// principalFromContext.
+func withPrincipal(ctx context.Context, principal acl.Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// principalFromContext returns the principal stored by the incoming-request
+// middleware, or the empty principal if none was set.
+func principalFromContext(ctx context.Context) acl.Principal {
+	if p, ok := ctx.Value(principalContextKey{}).(acl.Principal); ok {
+		return p
+	}
+	return ""
+}
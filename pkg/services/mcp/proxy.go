@@ -0,0 +1,44 @@
+package mcp
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// buildProxyHTTPClient returns an *http.Client configured to egress through
+// cfg.Proxy (honoring cfg.NoProxy), or nil if cfg.Proxy is unset. It is used to
+// route SSE and streamable HTTP upstream connections through a corporate
+// egress proxy; the OAuth loopback callback listener never uses it.
+func buildProxyHTTPClient(cfg settings.MCPServerConfig) (*http.Client, error) {
+	if cfg.Proxy == "" {
+		return nil, nil
+	}
+
+	if len(cfg.NoProxy) == 0 {
+		proxyURL, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url %q: %w", cfg.Proxy, err)
+		}
+		return &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}, nil
+	}
+
+	proxyConfig := &httpproxy.Config{
+		HTTPProxy:  cfg.Proxy,
+		HTTPSProxy: cfg.Proxy,
+		NoProxy:    strings.Join(cfg.NoProxy, ","),
+	}
+	proxyFunc := proxyConfig.ProxyFunc()
+
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: func(req *http.Request) (*url.URL, error) {
+				return proxyFunc(req.URL)
+			},
+		},
+	}, nil
+}
@@ -1,36 +1,52 @@
 package mcp
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/acl"
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/logging"
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/toolpolicy"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// mcpSessionHeader is the header the streamable HTTP transport uses to carry
+// the session id: present on every request after "initialize", and echoed
+// back in the response headers for the "initialize" call itself.
+const mcpSessionHeader = "Mcp-Session-Id"
+
 func NewServer(listenAddr string) *Server {
 	hooks := &server.Hooks{}
 
+	srv := &Server{
+		listenAddr:   listenAddr,
+		httpServer:   nil,
+		stateManager: NewStateManager(),
+	}
+
 	mcpServer := server.NewMCPServer("mcp-bouncer", "0.0.1",
 		server.WithToolCapabilities(true),
-		server.WithHooks(hooks))
+		server.WithHooks(hooks),
+		server.WithToolFilter(srv.filterTools))
 	streamableHttp := server.NewStreamableHTTPServer(mcpServer)
 	mux := http.NewServeMux()
-	mux.Handle("/mcp", streamableHttp)
+	mux.Handle("/mcp", principalMiddleware(srv.metricsMiddleware(streamableHttp)))
+	mux.Handle("/metrics", srv.metricsEndpoint())
 
-	httpServer := &http.Server{
+	srv.mcp = mcpServer
+	srv.httpServer = &http.Server{
 		Addr:    listenAddr,
 		Handler: mux,
 	}
 
-	srv := &Server{
-		listenAddr: listenAddr,
-		mcp:        mcpServer,
-		httpServer: httpServer,
-	}
-
 	srv.incomingClients = NewIncomingClientRegistry(srv)
 
 	hooks.AddAfterInitialize(srv.handleAfterInitialize)
@@ -49,6 +65,154 @@ type Server struct {
 	clientManager   *ClientManager
 	eventEmitter    func(name string, data any)
 	incomingClients *IncomingClientRegistry
+	acl             *acl.ACL
+	toolPolicy      *toolpolicy.Engine
+	stateManager    *StateManager
+	metricsEnabled  bool
+}
+
+// SetMetricsEnabled toggles whether the /metrics endpoint serves the process's
+// Prometheus collectors, opt-in since it exposes upstream server names and
+// per-tool call volumes to anyone who can reach the listen address.
+func (s *Server) SetMetricsEnabled(enabled bool) {
+	s.metricsEnabled = enabled
+}
+
+// metricsEndpoint wraps promhttp.Handler so /metrics 404s until
+// SetMetricsEnabled(true) is called, rather than being registered unconditionally.
+func (s *Server) metricsEndpoint() http.Handler {
+	handler := promhttp.Handler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.metricsEnabled {
+			http.NotFound(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// principalMiddleware tags each incoming request's context with the ACL principal
+// derived from its bearer token, mTLS certificate, or X-MCP-Client-Id header, so
+// downstream hooks and tool handlers can consult it.
+func principalMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := withPrincipal(r.Context(), principalFromRequest(r))
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			ctx = withIncomingAuthHeader(ctx, auth)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// metricsMiddleware records per-incoming-client activity in s.incomingClients:
+// the JSON-RPC method, bytes transferred in each direction, and how long the
+// request took. The session id is read from the Mcp-Session-Id request
+// header set by the streamable HTTP transport on every call after
+// "initialize"; for "initialize" itself, the id isn't known until the
+// transport assigns one and echoes it back in the response header, so the
+// in-flight count isn't tracked for that one call.
+func (s *Server) metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var bodyBytes []byte
+		if r.Body != nil {
+			bodyBytes, _ = io.ReadAll(r.Body)
+			r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		}
+		method := jsonRPCMethod(bodyBytes)
+
+		sessionID := r.Header.Get(mcpSessionHeader)
+		if sessionID != "" {
+			s.incomingClients.BeginRequest(sessionID)
+		}
+
+		rec := &responseByteCounter{ResponseWriter: w}
+		start := time.Now()
+		next.ServeHTTP(rec, r)
+		duration := time.Since(start)
+
+		if sessionID == "" {
+			sessionID = rec.Header().Get(mcpSessionHeader)
+		}
+		if sessionID != "" {
+			s.incomingClients.RecordRequest(sessionID, method, int64(len(bodyBytes)), rec.bytesWritten, duration)
+		}
+	})
+}
+
+// jsonRPCMethod best-effort extracts the "method" field from a JSON-RPC
+// request body, returning "" if body isn't a single JSON-RPC object (e.g. a
+// batch request, or unparseable).
+func jsonRPCMethod(body []byte) string {
+	var req struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+	return req.Method
+}
+
+// responseByteCounter wraps an http.ResponseWriter to count bytes written,
+// for metricsMiddleware's bytes-out tracking, passing through Flush for the
+// streamable HTTP transport's chunked/SSE responses.
+type responseByteCounter struct {
+	http.ResponseWriter
+	bytesWritten int64
+}
+
+func (w *responseByteCounter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.bytesWritten += int64(n)
+	return n, err
+}
+
+func (w *responseByteCounter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// SetACL installs the ACL policy the bouncer should enforce for incoming requests.
+func (s *Server) SetACL(a *acl.ACL) {
+	s.acl = a
+}
+
+// SetToolPolicy installs the tool exposure policy the bouncer should enforce
+// when advertising and calling tools on behalf of incoming MCP clients.
+func (s *Server) SetToolPolicy(policy *toolpolicy.Engine) {
+	s.toolPolicy = policy
+}
+
+// filterTools is registered as the MCP server's tool filter, hiding from the
+// incoming session's tools/list response any tool denied by either the ACL
+// (so different principals see different bouncer surfaces, not just
+// different call permissions) or the tool exposure policy.
+func (s *Server) filterTools(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	if s.toolPolicy == nil && s.acl == nil {
+		return tools
+	}
+
+	clientName := ""
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		if ic, ok := s.incomingClients.Get(session.SessionID()); ok {
+			clientName = ic.Name
+		}
+	}
+	principal := principalFromContext(ctx)
+
+	filtered := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		serverName, toolName, _ := strings.Cut(tool.Name, ":")
+		if !s.acl.Allowed(principal, serverName, toolName) {
+			slog.Info("dropping tool from tools/list due to ACLs", "tool", tool.Name, "principal", principal)
+			continue
+		}
+		if s.toolPolicy != nil && !s.toolPolicy.Allowed(tool.Name, clientName) {
+			continue
+		}
+		filtered = append(filtered, tool)
+	}
+	return filtered
 }
 
 func (s *Server) Start(ctx context.Context) error {
@@ -64,7 +228,11 @@ func (s *Server) Start(ctx context.Context) error {
 	case <-ctx.Done():
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 		defer cancel()
-		return s.httpServer.Shutdown(shutdownCtx)
+		err := s.httpServer.Shutdown(shutdownCtx)
+		if markErr := s.stateManager.MarkClean(); markErr != nil {
+			slog.Warn("Failed to write clean shutdown marker", "error", markErr)
+		}
+		return err
 	}
 }
 
@@ -73,6 +241,11 @@ func (s *Server) GetClientManager() *ClientManager {
 	return s.clientManager
 }
 
+// GetStateManager returns the durable client state manager
+func (s *Server) GetStateManager() *StateManager {
+	return s.stateManager
+}
+
 func (s *Server) GetIncomingClients() []IncomingClient {
 	if s.incomingClients == nil {
 		return []IncomingClient{}
@@ -80,6 +253,16 @@ func (s *Server) GetIncomingClients() []IncomingClient {
 	return s.incomingClients.List()
 }
 
+// GetIncomingClientStats returns every incoming client paired with a
+// deep-copied view of its activity stats, for the Wails frontend's live
+// activity panel.
+func (s *Server) GetIncomingClientStats() []IncomingClientSnapshot {
+	if s.incomingClients == nil {
+		return []IncomingClientSnapshot{}
+	}
+	return s.incomingClients.Snapshot()
+}
+
 // SetEventEmitter sets a callback to emit events to the application layer
 func (s *Server) SetEventEmitter(emitter func(name string, data any)) {
 	s.eventEmitter = emitter
@@ -98,7 +281,11 @@ func (s *Server) handleAfterInitialize(ctx context.Context, id any, req *mcp.Ini
 	if session == nil {
 		return
 	}
-	s.incomingClients.AddOrUpdate(session.SessionID(), req.Params.ClientInfo.Name, req.Params.ClientInfo.Version, "")
+	ctx = logging.WithIncomingClient(ctx, session.SessionID())
+	principal := principalFromContext(ctx)
+	authHeader, _ := incomingAuthHeaderFromContext(ctx)
+	logging.FromContext(ctx).Info("Incoming client connected", "name", req.Params.ClientInfo.Name)
+	s.incomingClients.AddOrUpdate(session.SessionID(), req.Params.ClientInfo.Name, req.Params.ClientInfo.Version, "", principal, authHeader)
 	s.EmitEvent(EventIncomingClientConnected, map[string]any{
 		"id":           session.SessionID(),
 		"name":         req.Params.ClientInfo.Name,
@@ -112,12 +299,14 @@ func (s *Server) handleAfterInitialize(ctx context.Context, id any, req *mcp.Ini
 // handleUnregisterSession handles the unregister session hook
 func (s *Server) handleUnregisterSession(ctx context.Context, session server.ClientSession) {
 	id := session.SessionID()
+	logger := logging.FromContext(logging.WithIncomingClient(ctx, id))
 	if s.incomingClients.Remove(id) {
+		logger.Info("Incoming client disconnected")
 		s.EmitEvent(EventIncomingClientDisconnected, map[string]any{
 			"id": id,
 		})
 		s.EmitEvent(EventIncomingClientsUpdated, s.incomingClients.List())
 	} else {
-		slog.Debug("UnregisterSession for unknown incoming client", "session_id", id)
+		logger.Debug("UnregisterSession for unknown incoming client")
 	}
 }
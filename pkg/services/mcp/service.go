@@ -5,20 +5,75 @@ import (
 	"fmt"
 	"log/slog"
 	"math/rand"
+	"os"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/acl"
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/config"
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/logging"
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/toolpolicy"
 	"github.com/catkins/mcp-bouncer/pkg/services/settings"
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
 type MCPService struct {
-	listenAddr     string
-	callbacks      []func(e *application.CustomEvent)
-	callbacksMutex sync.RWMutex
-	server         *Server
-	settings       *settings.SettingsService
+	listenAddr       string
+	callbacks        []func(e *application.CustomEvent)
+	callbacksMutex   sync.RWMutex
+	server           *Server
+	settings         *settings.SettingsService
+	providers        []config.Provider
+	configAggregator *config.Aggregator
+	resetState       bool
+	logHandler       slog.Handler
+
+	eventBus      map[chan Event]struct{}
+	eventBusMutex sync.RWMutex
+}
+
+// eventBusBufferSize bounds how many events a SubscribeEvents channel holds
+// before emitEvent starts dropping the oldest buffered event to make room for
+// the newest one, so one slow SSE consumer can't block event delivery to
+// everyone else.
+const eventBusBufferSize = 32
+
+// Event is a named event with free-form data, delivered to SubscribeEvents
+// subscribers in the same shape emitEvent hands to Wails callbacks.
+type Event struct {
+	Name string
+	Data any
+}
+
+// Option configures optional behavior on an MCPService at construction time.
+type Option func(*MCPService)
+
+// WithProvider registers an additional dynamic-configuration Provider. Providers
+// are merged in registration order, with the built-in settings-backed provider
+// registered first; later providers take precedence when server names collide.
+func WithProvider(provider config.Provider) Option {
+	return func(s *MCPService) {
+		s.providers = append(s.providers, provider)
+	}
+}
+
+// WithLogHandler overrides the slog.Handler the logging facade builds its base
+// logger from, e.g. so tests can assert on captured log records.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(s *MCPService) {
+		s.logHandler = handler
+	}
+}
+
+// WithResetState wipes any durable client state left over from a previous run
+// before ServiceStartup loads it, for support scenarios where persisted state is
+// suspected to be the problem.
+func WithResetState() Option {
+	return func(s *MCPService) {
+		s.resetState = true
+	}
 }
 
 // GetIncomingClients returns the list of active incoming clients connected to the streamable HTTP endpoint
@@ -29,33 +84,126 @@ func (s *MCPService) GetIncomingClients() []IncomingClient {
 	return s.server.GetIncomingClients()
 }
 
+// GetIncomingClientStats returns every incoming client paired with a
+// deep-copied view of its activity stats, for the UI's live activity panel.
+func (s *MCPService) GetIncomingClientStats() []IncomingClientSnapshot {
+	if s.server == nil {
+		return []IncomingClientSnapshot{}
+	}
+	return s.server.GetIncomingClientStats()
+}
+
+// GetToolPolicy returns the configured tool exposure policy, if any.
+func (s *MCPService) GetToolPolicy() toolpolicy.Policy {
+	if s.settings == nil {
+		return toolpolicy.Policy{}
+	}
+	return s.settings.GetToolPolicy()
+}
+
+// GetTokenStoreMode returns the configured OAuth token storage mode.
+func (s *MCPService) GetTokenStoreMode() settings.TokenStoreMode {
+	if s.settings == nil {
+		return settings.TokenStoreAuto
+	}
+	return s.settings.GetTokenStoreMode()
+}
+
+// SetTokenStoreMode persists the OAuth token storage mode and reconciles it
+// into the running process, emitting EventTokenStoreModeChanged on success.
+func (s *MCPService) SetTokenStoreMode(mode settings.TokenStoreMode) error {
+	if s.settings == nil {
+		return fmt.Errorf("settings service not available")
+	}
+	if err := s.settings.SetTokenStoreMode(mode); err != nil {
+		return err
+	}
+	SetTokenStoreMode(mode)
+	s.emitEvent(EventTokenStoreModeChanged, mode)
+	return nil
+}
+
+// GetPersistedState returns the durable snapshot of previously started clients,
+// for the UI to display alongside live status.
+func (s *MCPService) GetPersistedState() PersistedState {
+	if s.server == nil {
+		return PersistedState{Clients: map[string]PersistedClientState{}}
+	}
+	return s.server.GetStateManager().Snapshot()
+}
+
+// GetClientLogs returns the most recent n log records captured for client
+// (all retained records if n <= 0), for the UI to display alongside live status.
+func (s *MCPService) GetClientLogs(client string, n int) []logging.Record {
+	return logging.Ring().Tail(client, n)
+}
+
 const defaultListenAddr = "localhost:8091"
 
-func NewMCPService(settingsService *settings.SettingsService) *MCPService {
-	return &MCPService{
-		listenAddr: defaultListenAddr,
-		server:     NewServer(defaultListenAddr),
-		settings:   settingsService,
+func NewMCPService(settingsService *settings.SettingsService, opts ...Option) *MCPService {
+	s := &MCPService{
+		listenAddr:       defaultListenAddr,
+		server:           NewServer(defaultListenAddr),
+		settings:         settingsService,
+		configAggregator: config.NewAggregator(),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 func (s *MCPService) ServiceStartup(ctx context.Context, options application.ServiceOptions) error {
+	var handler slog.Handler
+	switch {
+	case s.logHandler != nil:
+		handler = s.logHandler
+	case s.settings != nil && s.settings.GetLogFormat() == settings.LogFormatJSON:
+		handler = slog.NewJSONHandler(os.Stderr, nil)
+	default:
+		handler = slog.NewTextHandler(os.Stderr, nil)
+	}
+	handler = logging.NewRingHandler(handler, logging.Ring(), func(client string, rec logging.Record) {
+		s.emitEvent(EventClientLogAppended, map[string]any{
+			"server_name": client,
+			"record":      rec,
+		})
+	})
+	logging.SetBase(slog.New(handler))
+
 	if s.settings != nil {
 		s.listenAddr = s.settings.GetListenAddr()
 		s.server = NewServer(s.listenAddr)
+		s.server.SetACL(acl.New(s.settings.GetACLPolicies()))
+		s.reconcileToolPolicy()
+		SetTokenStoreMode(s.settings.GetTokenStoreMode())
+		s.server.SetMetricsEnabled(s.settings.GetMetricsEnabled())
 		// Bridge server-emitted events to the app event bus
 		s.server.SetEventEmitter(func(name string, data any) {
 			s.emitEvent(name, data)
 		})
 
 		s.settings.Subscribe(func(event *application.CustomEvent) {
+			if strings.HasPrefix(event.Name, "server:") {
+				// Forward granular per-server changes (e.g. from an external
+				// edit to settings.json picked up by the settings file
+				// watcher) onto the app event bus for the UI to consume.
+				s.emitEvent(event.Name, event.Data)
+			}
 			if event.Name == "settings:updated" {
+				s.reconcileToolPolicy()
+				SetTokenStoreMode(s.settings.GetTokenStoreMode())
+				s.server.SetMetricsEnabled(s.settings.GetMetricsEnabled())
+
 				// Check if listen address changed
 				newAddr := s.settings.GetListenAddr()
 				if newAddr != s.listenAddr {
 					slog.Info("Listen address changed, reloading all clients", "old", s.listenAddr, "new", newAddr)
 					s.listenAddr = newAddr
 					s.server = NewServer(s.listenAddr)
+					s.server.SetACL(acl.New(s.settings.GetACLPolicies()))
+					s.reconcileToolPolicy()
+					s.server.SetMetricsEnabled(s.settings.GetMetricsEnabled())
 					s.server.SetEventEmitter(func(name string, data any) {
 						s.emitEvent(name, data)
 					})
@@ -69,20 +217,81 @@ func (s *MCPService) ServiceStartup(ctx context.Context, options application.Ser
 		})
 	}
 
+	stateManager := s.server.GetStateManager()
+	if s.resetState {
+		if err := stateManager.Reset(); err != nil {
+			slog.Error("Failed to reset persisted client state", "error", err)
+		}
+	}
+
+	wasClean := stateManager.WasCleanShutdown()
+	if err := stateManager.Load(); err != nil {
+		slog.Error("Failed to load persisted client state", "error", err)
+	}
+	if !wasClean {
+		for name, persisted := range stateManager.Snapshot().Clients {
+			// We can't reuse a surviving stdio child's pipes (mcp-go's
+			// transport.Interface doesn't hand back the *exec.Cmd to
+			// re-wrap, the same gap watchStdioProcess works around for
+			// exit detection), so a genuinely still-alive orphan can't be
+			// re-adopted - only reaped, with the configured provider left
+			// to start a fresh client in its place.
+			action := "recovered"
+			if persisted.PID != 0 && processAlive(persisted.PID) {
+				if err := killProcess(persisted.PID); err != nil {
+					slog.Warn("Failed to reap orphaned client process", "name", name, "pid", persisted.PID, "error", err)
+				} else {
+					slog.Warn("Reaped orphaned client process left running by a prior crashed bouncer run", "name", name, "pid", persisted.PID)
+					action = "reaped"
+				}
+			} else {
+				slog.Warn("Recovering client left running by a prior crashed bouncer run", "name", name)
+			}
+			s.emitEvent(EventClientError, map[string]any{
+				"server_name": name,
+				"action":      action,
+			})
+			stateManager.ForgetClient(name)
+		}
+	}
+
 	// Start the server
 	go func() {
 		s.server.Start(ctx)
 	}()
 
-	// Load clients from settings asynchronously
+	// Drive client start/stop/restart from every configured Provider. The
+	// settings-backed provider is always present so the desktop UX is unchanged
+	// even when no extra providers are registered.
+	var providers []config.Provider
+	if s.settings != nil {
+		providers = append(providers, config.NewSettingsProvider(s.settings))
+	}
+	providers = append(providers, s.providers...)
+	msgCh := make(chan config.ConfigMessage)
+	for _, provider := range providers {
+		// Record each provider with the aggregator up front so precedence
+		// follows registration order, not whichever provider reports first.
+		s.configAggregator.Merge(config.ConfigMessage{ProviderName: provider.Name()})
+		go func(provider config.Provider) {
+			if err := provider.Provide(ctx, msgCh); err != nil && ctx.Err() == nil {
+				slog.Error("Config provider stopped", "provider", provider.Name(), "error", err)
+			}
+		}(provider)
+	}
+
 	go func() {
-		if s.settings != nil {
-			settings := s.settings.GetSettings()
-			if settings != nil {
-				slog.Info("Loading clients from settings", "client_count", len(settings.MCPServers))
-				if err := s.server.GetClientManager().LoadClientsFromSettings(ctx, settings); err != nil {
-					slog.Error("Failed to load clients from settings", "error", err)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-msgCh:
+				merged := s.configAggregator.Merge(msg)
+				slog.Info("Reconciling MCP servers from providers", "provider", msg.ProviderName, "total_servers", len(merged))
+				if err := s.server.GetClientManager().LoadClientsFromSettings(ctx, &settings.Settings{MCPServers: merged}); err != nil {
+					slog.Error("Failed to reconcile clients from providers", "error", err)
 				}
+				s.emitEvent(EventServersUpdated, map[string]any{})
 			}
 		}
 	}()
@@ -90,6 +299,37 @@ func (s *MCPService) ServiceStartup(ctx context.Context, options application.Ser
 	return nil
 }
 
+// reconcileToolPolicy recompiles the configured tool exposure policy and
+// installs it on the current server, if any.
+func (s *MCPService) reconcileToolPolicy() {
+	if s.settings == nil || s.server == nil {
+		return
+	}
+	engine, err := toolpolicy.New(s.settings.GetToolPolicy())
+	if err != nil {
+		slog.Error("Failed to compile tool policy, leaving tools unfiltered", "error", err)
+		return
+	}
+	s.server.SetToolPolicy(engine)
+}
+
+// SetToolPolicy persists policy and reconciles it into the running server
+// without requiring a restart, emitting EventToolPolicyChanged on success.
+func (s *MCPService) SetToolPolicy(policy toolpolicy.Policy) error {
+	if s.settings == nil {
+		return fmt.Errorf("settings service not available")
+	}
+	if _, err := toolpolicy.New(policy); err != nil {
+		return err
+	}
+	if err := s.settings.SetToolPolicy(policy); err != nil {
+		return err
+	}
+	s.reconcileToolPolicy()
+	s.emitEvent(EventToolPolicyChanged, policy)
+	return nil
+}
+
 func (s *MCPService) IsActive() bool {
 	return s.server.active
 }
@@ -130,6 +370,57 @@ func (s *MCPService) GetCallbackCount() int {
 	return len(s.callbacks)
 }
 
+// SubscribeEvents registers a buffered channel that receives every event this
+// service emits, for consumers like the REST API's SSE endpoint that can't
+// use the Wails CustomEvent callback mechanism. The returned unsubscribe func
+// must be called once the consumer is done, typically via defer, to stop
+// delivery and release the channel.
+func (s *MCPService) SubscribeEvents() (<-chan Event, func()) {
+	ch := make(chan Event, eventBusBufferSize)
+
+	s.eventBusMutex.Lock()
+	if s.eventBus == nil {
+		s.eventBus = make(map[chan Event]struct{})
+	}
+	s.eventBus[ch] = struct{}{}
+	s.eventBusMutex.Unlock()
+
+	unsubscribe := func() {
+		s.eventBusMutex.Lock()
+		defer s.eventBusMutex.Unlock()
+		if _, ok := s.eventBus[ch]; ok {
+			delete(s.eventBus, ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe
+}
+
+// publishToEventBus fans an event out to every SubscribeEvents channel. A
+// subscriber whose buffer is full has its oldest queued event dropped to make
+// room, so one slow consumer can't stall delivery to the others or to
+// emitEvent's caller.
+func (s *MCPService) publishToEventBus(name string, data any) {
+	s.eventBusMutex.RLock()
+	defer s.eventBusMutex.RUnlock()
+
+	event := Event{Name: name, Data: data}
+	for ch := range s.eventBus {
+		select {
+		case ch <- event:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
 func (s *MCPService) List() ([]settings.MCPServerConfig, error) {
 	if s.settings != nil {
 		return s.settings.GetMCPServers(), nil
@@ -407,26 +698,30 @@ func (s *MCPService) GetClientStatus() map[string]ClientStatus {
 	return make(map[string]ClientStatus)
 }
 
-// ReloadClients reloads all clients from settings
+// ReloadClients broadcasts a fresh settings snapshot to the provider aggregator
+// and reconciles clients against the resulting merged view, rather than a one-shot
+// settings-only reload.
 func (s *MCPService) ReloadClients() error {
 	if s.settings != nil && s.server != nil {
-		settings := s.settings.GetSettings()
-		if settings != nil {
-			err := s.server.GetClientManager().LoadClientsFromSettings(context.Background(), settings)
-			if err != nil {
-				return err
-			}
-			s.emitEvent(EventServersUpdated, map[string]any{})
-			return nil
+		merged := s.configAggregator.Merge(config.ConfigMessage{
+			ProviderName: "settings",
+			Servers:      s.settings.GetMCPServers(),
+		})
+		if err := s.server.GetClientManager().LoadClientsFromSettings(context.Background(), &settings.Settings{MCPServers: merged}); err != nil {
+			return err
 		}
+		s.emitEvent(EventServersUpdated, map[string]any{})
+		return nil
 	}
 	return fmt.Errorf("settings or server not available")
 }
 
-// GetClientTools returns the tools for a specific client
-func (s *MCPService) GetClientTools(clientName string) ([]map[string]interface{}, error) {
+// GetClientTools returns the tools for a specific client, filtered by the ACL
+// policy configured for principal (the empty principal if the caller doesn't have
+// one, e.g. calls originating from the desktop UI itself).
+func (s *MCPService) GetClientTools(clientName string, principal acl.Principal) ([]map[string]interface{}, error) {
 	if s.server != nil {
-		tools, err := s.server.GetClientManager().GetClientTools(clientName)
+		tools, err := s.server.GetClientManager().GetClientTools(clientName, principal)
 		if err != nil {
 			// Auto-start missing client if enabled, then retry once
 			if strings.Contains(err.Error(), "not found") && s.settings != nil {
@@ -440,7 +735,7 @@ func (s *MCPService) GetClientTools(clientName string) ([]map[string]interface{}
 						}
 						// Retry
 						var retryErr error
-						tools, retryErr = s.server.GetClientManager().GetClientTools(clientName)
+						tools, retryErr = s.server.GetClientManager().GetClientTools(clientName, principal)
 						if retryErr != nil {
 							return nil, retryErr
 						}
@@ -493,7 +788,10 @@ func (s *MCPService) ToggleTool(clientName string, toolName string, enabled bool
 }
 
 func (s *MCPService) emitEvent(name string, data any) {
-	slog.Info("Emitting event", "name", name, "data", data, "callback_count", s.GetCallbackCount())
+	logger := logging.FromContext(logging.WithEvent(context.Background(), name))
+	logger.Info("Emitting event", "data", data, "callback_count", s.GetCallbackCount())
+
+	s.publishToEventBus(name, data)
 
 	// Copy callbacks under read lock, then invoke without holding the lock
 	s.callbacksMutex.RLock()
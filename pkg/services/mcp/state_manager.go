@@ -0,0 +1,213 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+// PersistedClientState is the durable snapshot recorded for a single running
+// client, so the bouncer can recover cleanly across restarts instead of leaking
+// child processes or silently dropping OAuth state.
+type PersistedClientState struct {
+	Name               string    `json:"name"`
+	PID                int       `json:"pid,omitempty"`
+	OAuthAuthenticated bool      `json:"oauth_authenticated"`
+	LastHealthy        time.Time `json:"last_healthy,omitempty"`
+
+	// DisabledTools is the set of tool names (unprefixed, as returned by the
+	// upstream server) that ToggleTool has turned off for this client, so a
+	// restart doesn't silently re-expose a tool an operator disabled.
+	DisabledTools []string `json:"disabled_tools,omitempty"`
+}
+
+// PersistedState is the full snapshot written to disk.
+type PersistedState struct {
+	Clients map[string]PersistedClientState `json:"clients"`
+}
+
+// StateManager persists a JSON snapshot of every started client under the user
+// config dir and tracks whether the previous run shut down cleanly.
+type StateManager struct {
+	mu        sync.Mutex
+	filePath  string
+	cleanPath string
+	state     PersistedState
+}
+
+// NewStateManager creates a StateManager using the default state file location.
+func NewStateManager() *StateManager {
+	dir := filepath.Join(xdg.ConfigHome, "mcp-bouncer")
+	return &StateManager{
+		filePath:  filepath.Join(dir, "state.json"),
+		cleanPath: filepath.Join(dir, "state.clean"),
+		state:     PersistedState{Clients: make(map[string]PersistedClientState)},
+	}
+}
+
+// Load reads the persisted snapshot from disk, if one exists.
+func (m *StateManager) Load() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, err := os.ReadFile(m.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read persisted state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &m.state); err != nil {
+		return fmt.Errorf("failed to parse persisted state: %w", err)
+	}
+	return nil
+}
+
+// save writes the current snapshot to disk. Assumes the lock is held.
+func (m *StateManager) save() error {
+	if err := os.MkdirAll(filepath.Dir(m.filePath), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(m.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal persisted state: %w", err)
+	}
+
+	return os.WriteFile(m.filePath, data, 0600)
+}
+
+// RecordClient persists the current state of a running client.
+func (m *StateManager) RecordClient(client PersistedClientState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state.Clients[client.Name] = client
+	if err := m.save(); err != nil {
+		slog.Warn("Failed to persist client state", "name", client.Name, "error", err)
+	}
+}
+
+// SetToolEnabled records toolName's enabled state in clientName's persisted
+// entry, so a restart restores whatever ToggleTool calls were made before it.
+// A no-op if clientName has no persisted entry (e.g. it never finished
+// starting).
+func (m *StateManager) SetToolEnabled(clientName, toolName string, enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	client, ok := m.state.Clients[clientName]
+	if !ok {
+		return
+	}
+
+	idx := slices.Index(client.DisabledTools, toolName)
+	switch {
+	case enabled && idx >= 0:
+		client.DisabledTools = slices.Delete(client.DisabledTools, idx, idx+1)
+	case !enabled && idx < 0:
+		client.DisabledTools = append(client.DisabledTools, toolName)
+	default:
+		return
+	}
+
+	m.state.Clients[clientName] = client
+	if err := m.save(); err != nil {
+		slog.Warn("Failed to persist tool toggle", "name", clientName, "tool", toolName, "error", err)
+	}
+}
+
+// ForgetClient removes a client's persisted state, e.g. after a clean stop.
+func (m *StateManager) ForgetClient(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.state.Clients, name)
+	if err := m.save(); err != nil {
+		slog.Warn("Failed to persist client state", "name", name, "error", err)
+	}
+}
+
+// Snapshot returns a copy of the currently persisted state, for the UI.
+func (m *StateManager) Snapshot() PersistedState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clients := make(map[string]PersistedClientState, len(m.state.Clients))
+	for k, v := range m.state.Clients {
+		clients[k] = v
+	}
+	return PersistedState{Clients: clients}
+}
+
+// WasCleanShutdown reports whether the previous run left its clean marker behind,
+// and removes the marker so the current run starts "dirty" until it shuts down
+// cleanly itself.
+func (m *StateManager) WasCleanShutdown() bool {
+	_, err := os.Stat(m.cleanPath)
+	clean := err == nil
+	_ = os.Remove(m.cleanPath)
+	return clean
+}
+
+// MarkClean writes the clean-shutdown marker. Call on context cancellation once
+// every client has stopped.
+func (m *StateManager) MarkClean() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(m.cleanPath), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	return os.WriteFile(m.cleanPath, []byte(time.Now().Format(time.RFC3339)), 0600)
+}
+
+// processAlive reports whether pid still refers to a running process, by
+// sending it the null signal: this checks for existence/permission without
+// actually signaling the process.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+// killProcess terminates an orphaned stdio child left running by a crashed
+// prior bouncer run. We have no way to recover its stdio pipes (see the
+// ServiceStartup recovery pass), so it can only be reaped, not re-adopted.
+func killProcess(pid int) error {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Kill()
+}
+
+// Reset wipes the persisted snapshot and clean marker, for the --reset-state
+// support scenario.
+func (m *StateManager) Reset() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.state = PersistedState{Clients: make(map[string]PersistedClientState)}
+	if err := os.Remove(m.filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove persisted state: %w", err)
+	}
+	if err := os.Remove(m.cleanPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove clean marker: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Package toolpolicy implements ordered, glob-based allow/deny rules deciding
+// which upstream tools are exposed to which incoming MCP client, turning the
+// bouncer's "all tools always exposed" default into a configurable policy.
+package toolpolicy
+
+import (
+	"fmt"
+
+	"github.com/gobwas/glob"
+)
+
+// Effect is the outcome a Rule applies when it matches.
+type Effect string
+
+const (
+	Allow Effect = "allow"
+	Deny  Effect = "deny"
+)
+
+// Rule matches a prefixed tool name ("server:tool") and, optionally, the
+// incoming client's name, applying Effect to the first match.
+type Rule struct {
+	Match   string   `json:"match"`
+	Clients []string `json:"clients,omitempty"`
+	Effect  Effect   `json:"effect"`
+}
+
+// Policy is the ordered list of rules persisted in settings.
+type Policy struct {
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+type compiledRule struct {
+	match   glob.Glob
+	clients []glob.Glob
+	effect  Effect
+}
+
+// Engine is a compiled Policy ready to evaluate tool/client pairs.
+type Engine struct {
+	rules []compiledRule
+}
+
+// New compiles policy into an Engine. An empty policy produces a fail-open
+// Engine that allows every tool.
+func New(policy Policy) (*Engine, error) {
+	rules := make([]compiledRule, 0, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		match, err := glob.Compile(rule.Match)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tool policy match glob %q: %w", rule.Match, err)
+		}
+
+		clients := make([]glob.Glob, 0, len(rule.Clients))
+		for _, pattern := range rule.Clients {
+			clientGlob, err := glob.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tool policy client glob %q: %w", pattern, err)
+			}
+			clients = append(clients, clientGlob)
+		}
+
+		rules = append(rules, compiledRule{match: match, clients: clients, effect: rule.Effect})
+	}
+	return &Engine{rules: rules}, nil
+}
+
+// Allowed reports whether prefixedToolName (e.g. "server:tool") may be
+// exposed to and called by clientName, evaluating rules in order and applying
+// the first match. A nil Engine, an Engine with no rules, or no matching rule
+// all default to allow.
+func (e *Engine) Allowed(prefixedToolName, clientName string) bool {
+	if e == nil {
+		return true
+	}
+	for _, rule := range e.rules {
+		if !rule.match.Match(prefixedToolName) {
+			continue
+		}
+		if len(rule.clients) > 0 && !matchesAny(rule.clients, clientName) {
+			continue
+		}
+		return rule.effect == Allow
+	}
+	return true
+}
+
+func matchesAny(globs []glob.Glob, value string) bool {
+	for _, g := range globs {
+		if g.Match(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package toolpolicy
+
+import "testing"
+
+func TestEngineAllowsEverythingWithNoRules(t *testing.T) {
+	engine, err := New(Policy{})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if !engine.Allowed("files:read_file", "any-client") {
+		t.Fatal("expected an empty policy to fail open")
+	}
+}
+
+func TestNilEngineAllowsEverything(t *testing.T) {
+	var engine *Engine
+
+	if !engine.Allowed("files:read_file", "any-client") {
+		t.Fatal("expected a nil *Engine to fail open")
+	}
+}
+
+func TestEngineDenyRuleBlocksMatchingTool(t *testing.T) {
+	engine, err := New(Policy{Rules: []Rule{
+		{Match: "files:write_*", Effect: Deny},
+	}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if engine.Allowed("files:write_file", "any-client") {
+		t.Fatal("expected files:write_file to be denied by the matching rule")
+	}
+	if !engine.Allowed("files:read_file", "any-client") {
+		t.Fatal("expected files:read_file to fall through to the default allow")
+	}
+}
+
+func TestEngineFirstMatchWins(t *testing.T) {
+	engine, err := New(Policy{Rules: []Rule{
+		{Match: "files:*", Effect: Deny},
+		{Match: "files:read_*", Effect: Allow},
+	}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if engine.Allowed("files:read_file", "any-client") {
+		t.Fatal("expected the first matching rule (deny files:*) to win over the later allow rule")
+	}
+}
+
+func TestEngineRuleScopedToClients(t *testing.T) {
+	engine, err := New(Policy{Rules: []Rule{
+		{Match: "files:*", Clients: []string{"trusted-*"}, Effect: Allow},
+		{Match: "files:*", Effect: Deny},
+	}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if !engine.Allowed("files:read_file", "trusted-admin") {
+		t.Fatal("expected a client matching the rule's Clients glob to be allowed")
+	}
+	if engine.Allowed("files:read_file", "untrusted-client") {
+		t.Fatal("expected a client not matching the rule's Clients glob to fall through to the deny rule")
+	}
+}
+
+func TestNewRejectsInvalidMatchGlob(t *testing.T) {
+	if _, err := New(Policy{Rules: []Rule{{Match: "[", Effect: Allow}}}); err == nil {
+		t.Fatal("expected an invalid match glob to fail compilation")
+	}
+}
+
+func TestNewRejectsInvalidClientGlob(t *testing.T) {
+	if _, err := New(Policy{Rules: []Rule{{Match: "*", Clients: []string{"["}, Effect: Allow}}}); err == nil {
+		t.Fatal("expected an invalid client glob to fail compilation")
+	}
+}
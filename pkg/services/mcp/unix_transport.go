@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/catkins/mcp-bouncer/pkg/services/settings"
+)
+
+// checkUnixSocketPermissions rejects a socket that other local users can
+// write to (and so potentially impersonate the MCP server), unless
+// allowWorldWritable opts out of the check.
+func checkUnixSocketPermissions(socketPath string, allowWorldWritable bool) error {
+	info, err := os.Stat(socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat unix socket %q: %w", socketPath, err)
+	}
+	if !allowWorldWritable && info.Mode()&0o002 != 0 {
+		return fmt.Errorf("unix socket %q is world-writable; set allow_world_writable_socket to override", socketPath)
+	}
+	return nil
+}
+
+// unixSocketEndpoint returns the pseudo-URL passed to the streamable HTTP
+// client for a TransportUnix server, built from cfg.Path (defaulting to
+// "/mcp"); the host is irrelevant since buildUnixSocketHTTPClient's
+// DialContext ignores it and always dials cfg.SocketPath instead.
+func unixSocketEndpoint(cfg settings.MCPServerConfig) string {
+	path := cfg.Path
+	if path == "" {
+		path = "/mcp"
+	}
+	return "http://unix" + path
+}
+
+// headerRoundTripper sets a fixed set of headers on every outgoing request,
+// used to apply MCPServerConfig.Headers to unix-socket clients the same way
+// transport.WithHeaders does for TCP-based transports.
+type headerRoundTripper struct {
+	next    http.RoundTripper
+	headers map[string]string
+}
+
+func (t *headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// buildUnixSocketHTTPClient returns an *http.Client whose every request is
+// dialed over the unix domain socket at socketPath instead of TCP, applying
+// headers (if any) to each request.
+func buildUnixSocketHTTPClient(socketPath string, headers map[string]string) *http.Client {
+	dialer := &net.Dialer{}
+	var rt http.RoundTripper = &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socketPath)
+		},
+	}
+	if len(headers) > 0 {
+		rt = &headerRoundTripper{next: rt, headers: headers}
+	}
+	return &http.Client{Transport: rt}
+}
@@ -2,54 +2,301 @@ package settings
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/adrg/xdg"
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/acl"
+	"github.com/catkins/mcp-bouncer/pkg/services/mcp/toolpolicy"
+	"github.com/fsnotify/fsnotify"
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
 
+// Event names emitted when the on-disk settings file changes without going
+// through Save(), e.g. a user hand-editing settings.json or a git pull of a
+// shared config, so the mcp.Server subsystem can reconcile individual
+// servers instead of bouncing every connection. The existing "settings:updated"
+// event still fires alongside these for any reload, granular or not.
+const (
+	EventServerAdded    = "server:added"
+	EventServerRemoved  = "server:removed"
+	EventServerUpdated  = "server:updated"
+	EventServerEnabled  = "server:enabled"
+	EventServerDisabled = "server:disabled"
+)
+
+// fileWatchDebounce coalesces bursts of filesystem events (e.g. an editor's
+// write-then-chmod, or a rename-over-file atomic save) into a single reload.
+const fileWatchDebounce = 250 * time.Millisecond
+
 // TransportType represents the type of transport for MCP server communication
 type TransportType string
 
 const (
-	TransportStdio           TransportType = "stdio"
-	TransportSSE             TransportType = "sse"
-	TransportStreamableHTTP  TransportType = "streamable_http"
+	TransportStdio          TransportType = "stdio"
+	TransportSSE            TransportType = "sse"
+	TransportStreamableHTTP TransportType = "streamable_http"
+	// TransportUnix speaks the streamable HTTP protocol over a Unix domain
+	// socket instead of TCP, for co-locating an MCP server on the same host
+	// without opening a port.
+	TransportUnix TransportType = "unix"
+)
+
+// OAuthFlow selects which OAuth 2.0 grant a server's authorization uses.
+type OAuthFlow string
+
+const (
+	OAuthFlowCode   OAuthFlow = "code"
+	OAuthFlowDevice OAuthFlow = "device"
+)
+
+// TLSClientAuthMode selects how pkg/api's REST server verifies client
+// certificates during the TLS handshake when TLSClientCAFile is configured.
+type TLSClientAuthMode string
+
+const (
+	// TLSClientAuthNone never requests a client certificate. The default.
+	TLSClientAuthNone TLSClientAuthMode = "none"
+	// TLSClientAuthVerifyIfGiven verifies a client certificate against
+	// TLSClientCAFile if one is presented, but allows the handshake to
+	// proceed without one.
+	TLSClientAuthVerifyIfGiven TLSClientAuthMode = "verify-if-given"
+	// TLSClientAuthRequired fails the handshake unless the client presents a
+	// certificate that verifies against TLSClientCAFile.
+	TLSClientAuthRequired TLSClientAuthMode = "verify-required"
+)
+
+// TokenStoreMode selects how OAuth tokens are persisted to disk.
+type TokenStoreMode string
+
+const (
+	// TokenStoreAuto uses the platform secret store (Keychain, Credential
+	// Manager, Secret Service) when one is available, falling back to
+	// FileTokenStore otherwise.
+	TokenStoreAuto TokenStoreMode = "auto"
+	// TokenStoreFile always uses FileTokenStore, e.g. for headless Linux
+	// hosts with no Secret Service daemon running.
+	TokenStoreFile TokenStoreMode = "file"
+)
+
+// APIToken is a hashed credential for pkg/api's REST server. The raw secret
+// is shown to the caller once, at creation time, and never persisted;
+// HashedToken stores only its SHA-256 hex digest so a leaked settings file
+// doesn't also leak usable tokens.
+type APIToken struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	HashedToken string    `json:"hashed_token"`
+	Scopes      []string  `json:"scopes"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// LogFormat selects the bouncer's process-wide log output encoding.
+type LogFormat string
+
+const (
+	LogFormatText LogFormat = "text"
+	LogFormatJSON LogFormat = "json"
 )
 
 // MCPServerConfig represents configuration for a single MCP server
 type MCPServerConfig struct {
-	Name        string            `json:"name"`
-	Description string            `json:"description"`
-	Transport   TransportType     `json:"transport"`
-	Command     string            `json:"command"`
-	Args        []string          `json:"args,omitempty"`
-	Env         map[string]string `json:"env,omitempty"`
-	Endpoint    string            `json:"endpoint,omitempty"`
-	Headers     map[string]string `json:"headers,omitempty"`
-	Enabled     bool              `json:"enabled"`
+	Name           string               `json:"name"`
+	Description    string               `json:"description"`
+	Transport      TransportType        `json:"transport"`
+	Command        string               `json:"command"`
+	Args           []string             `json:"args,omitempty"`
+	Env            map[string]string    `json:"env,omitempty"`
+	Endpoint       string               `json:"endpoint,omitempty"`
+	Headers        map[string]string    `json:"headers,omitempty"`
+
+	// SocketPath is the Unix domain socket startClientProcess dials for
+	// TransportUnix, e.g. "/run/mcp/foo.sock". Path carries the HTTP path to
+	// request over that socket (defaults to "/mcp" if empty); Headers above
+	// applies to the HTTP-over-UDS request the same way it does for
+	// TransportStreamableHTTP.
+	SocketPath string `json:"socket_path,omitempty"`
+	Path       string `json:"path,omitempty"`
+
+	// AllowWorldWritableSocket opts out of the startup check that otherwise
+	// rejects a TransportUnix SocketPath whose permissions let other local
+	// users write to it (and so potentially impersonate the MCP server).
+	AllowWorldWritableSocket bool `json:"allow_world_writable_socket,omitempty"`
+
+	Enabled        bool                 `json:"enabled"`
+	CircuitBreaker CircuitBreakerConfig `json:"circuit_breaker,omitempty"`
+	HealthCheck    HealthCheckConfig    `json:"health_check,omitempty"`
+	Proxy          string               `json:"proxy,omitempty"`
+	NoProxy        []string             `json:"no_proxy,omitempty"`
+	Labels         map[string]string    `json:"labels,omitempty"`
+	Tags           []string             `json:"tags,omitempty"`
+
+	// OAuthFlow selects the grant used by AuthorizeClient when RequiresAuth is
+	// set; OAuthFlowCode (default) uses the loopback browser callback,
+	// OAuthFlowDevice uses the RFC 8628 device authorization grant.
+	OAuthFlow                   OAuthFlow `json:"oauth_flow,omitempty"`
+	DeviceAuthorizationEndpoint string    `json:"device_authorization_endpoint,omitempty"`
+	TokenEndpoint               string    `json:"token_endpoint,omitempty"`
+	OAuthClientID               string    `json:"oauth_client_id,omitempty"`
+
+	// ForwardIncomingAuth, when true, forwards the connected incoming session's
+	// Authorization header to this upstream on every tool call instead of the
+	// bouncer's own credentials, falling back to the bouncer's credentials if the
+	// upstream rejects the forwarded identity.
+	ForwardIncomingAuth bool `json:"forward_incoming_auth,omitempty"`
+
+	// AuthHeaderMap remaps the incoming "Authorization" header to a different
+	// upstream header name, e.g. {"Authorization": "X-Forwarded-Authorization"}.
+	AuthHeaderMap map[string]string `json:"auth_header_map,omitempty"`
+
+	// OAuth overrides the scopes, redirect URI, and dynamic client registration
+	// metadata AuthorizeClient otherwise defaults to for this server. Nil means
+	// use the built-in defaults.
+	OAuth *OAuthSettings `json:"oauth,omitempty"`
+}
+
+// OAuthSettings customizes the authorization code flow AuthorizeClient runs
+// for a server whose requirements don't match the built-in defaults, e.g. a
+// server that demands "openid profile mcp:*" scopes or richer RFC 7591
+// dynamic client registration metadata than a bare client name.
+type OAuthSettings struct {
+	// Scopes overrides the default ["mcp.read", "mcp.write"] requested scopes.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// RedirectURI overrides the dynamically reserved loopback redirect URI
+	// startClientProcess otherwise generates for this server.
+	RedirectURI string `json:"redirect_uri,omitempty"`
+
+	// ClientName overrides the "mcp-bouncer" client name passed to
+	// RegisterClient.
+	ClientName string `json:"client_name,omitempty"`
+
+	// ClientURI, LogoURI, SoftwareID, and SoftwareVersion are optional RFC 7591
+	// dynamic client registration metadata fields.
+	ClientURI       string `json:"client_uri,omitempty"`
+	LogoURI         string `json:"logo_uri,omitempty"`
+	SoftwareID      string `json:"software_id,omitempty"`
+	SoftwareVersion string `json:"software_version,omitempty"`
+
+	// AdditionalMetadata carries any further registration metadata fields not
+	// modeled above verbatim into the registration request.
+	AdditionalMetadata map[string]any `json:"additional_metadata,omitempty"`
+}
+
+// TransportEqual reports whether a and other would produce the same upstream
+// transport, i.e. whether a running client needs to be restarted to pick up
+// other. Cosmetic fields such as Description, Labels, and Tags are
+// deliberately excluded so editing them doesn't bounce the connection.
+func (a MCPServerConfig) TransportEqual(other MCPServerConfig) bool {
+	return a.Transport == other.Transport &&
+		a.Command == other.Command &&
+		a.Endpoint == other.Endpoint &&
+		a.Proxy == other.Proxy &&
+		a.SocketPath == other.SocketPath &&
+		a.Path == other.Path &&
+		slices.Equal(a.Args, other.Args) &&
+		slices.Equal(a.NoProxy, other.NoProxy) &&
+		maps.Equal(a.Env, other.Env) &&
+		maps.Equal(a.Headers, other.Headers) &&
+		a.ForwardIncomingAuth == other.ForwardIncomingAuth &&
+		reflect.DeepEqual(a.OAuth, other.OAuth)
+}
+
+// HealthCheckConfig tunes the periodic liveness probe run against a single
+// upstream MCP server. Zero values fall back to the package defaults (15s
+// interval, 5s timeout, 3 consecutive failures to degrade, 1s initial backoff
+// with a 2x multiplier capped at 30s). Each reconnect starts a fresh client
+// whose backoff begins again at BackoffInitial, so in practice every
+// reconnect waits the initial backoff.
+type HealthCheckConfig struct {
+	Interval          time.Duration `json:"interval,omitempty"`
+	Timeout           time.Duration `json:"timeout,omitempty"`
+	FailureThreshold  int           `json:"failure_threshold,omitempty"`
+	BackoffInitial    time.Duration `json:"backoff_initial,omitempty"`
+	BackoffMax        time.Duration `json:"backoff_max,omitempty"`
+	BackoffMultiplier float64       `json:"backoff_multiplier,omitempty"`
+}
+
+// CircuitBreakerConfig tunes the rolling error-rate breaker placed around calls to
+// a single upstream MCP server. Zero values fall back to the package defaults
+// (50% error rate over at least 20 requests in a 30s window, 30s cool-down).
+type CircuitBreakerConfig struct {
+	ErrorRateThreshold float64       `json:"error_rate_threshold,omitempty"`
+	MinRequests        int           `json:"min_requests,omitempty"`
+	Window             time.Duration `json:"window,omitempty"`
+	CoolDown           time.Duration `json:"cool_down,omitempty"`
 }
 
 // Settings represents the application settings
 type Settings struct {
-	MCPServers []MCPServerConfig `json:"mcp_servers"`
-	ListenAddr string            `json:"listen_addr"`
-	AutoStart  bool              `json:"auto_start"`
+	MCPServers     []MCPServerConfig `json:"mcp_servers"`
+	ListenAddr     string            `json:"listen_addr"`
+	AutoStart      bool              `json:"auto_start"`
+	ACLPolicies    []acl.Policy      `json:"acl_policies,omitempty"`
+	LogFormat      LogFormat         `json:"log_format,omitempty"`
+	ToolPolicy     toolpolicy.Policy `json:"tool_policy,omitempty"`
+	TokenStoreMode TokenStoreMode    `json:"token_store_mode,omitempty"`
+
+	// MetricsEnabled opts into serving the Prometheus /metrics endpoint.
+	// Disabled by default so the subsystem doesn't expose tool call details
+	// and upstream names to anyone who can reach the listen address.
+	MetricsEnabled bool `json:"metrics_enabled,omitempty"`
+
+	// APITokens are the hashed credentials pkg/api's REST server accepts as
+	// bearer tokens, managed via its /api/tokens endpoints.
+	APITokens []APIToken `json:"api_tokens,omitempty"`
+
+	// APIListenAddr is the address pkg/api's REST server binds to, e.g.
+	// ":8080" or "127.0.0.1:0" to pick an ephemeral port. Empty defaults to
+	// ":8080".
+	APIListenAddr string `json:"api_listen_addr,omitempty"`
+
+	// GRPCListenAddr is the address pkg/api's BouncerService gRPC server
+	// binds to, e.g. ":50051" or "127.0.0.1:0" to pick an ephemeral port.
+	// Empty defaults to ":50051".
+	GRPCListenAddr string `json:"grpc_listen_addr,omitempty"`
+
+	// TLSCertFile and TLSKeyFile are PEM file paths for the REST server's
+	// certificate and private key. Both must be set to serve TLS; leaving
+	// either empty keeps the server on plain HTTP.
+	TLSCertFile string `json:"tls_cert_file,omitempty"`
+	TLSKeyFile  string `json:"tls_key_file,omitempty"`
+
+	// TLSClientCAFile is an optional PEM bundle of CAs trusted to sign client
+	// certificates for mTLS, consulted according to TLSClientAuthMode.
+	TLSClientCAFile string `json:"tls_client_ca_file,omitempty"`
+
+	// TLSClientAuthMode controls client certificate verification once
+	// TLSClientCAFile is set. Empty defaults to TLSClientAuthNone.
+	TLSClientAuthMode TLSClientAuthMode `json:"tls_client_auth_mode,omitempty"`
 }
 
 // SettingsService handles loading and saving application settings
 type SettingsService struct {
-	settings       *Settings
+	// mu guards settings itself: both the pointer (swapped wholesale by
+	// ReloadNow on an external file change) and the fields of the Settings
+	// it points to (mutated in place by AddMCPServer et al.). Every read or
+	// write of settings, anywhere in this file, must hold mu.
+	mu       sync.RWMutex
+	settings *Settings
+
 	filePath       string
 	callbacks      []func(e *application.CustomEvent)
 	callbacksMutex sync.RWMutex
+
+	watcherMutex sync.Mutex
+	lastSaved    [sha256.Size]byte
 }
 
 // NewSettingsService creates a new settings service
@@ -59,6 +306,7 @@ func NewSettingsService() *SettingsService {
 			MCPServers: []MCPServerConfig{},
 			ListenAddr: "localhost:8091",
 			AutoStart:  false,
+			LogFormat:  LogFormatText,
 		},
 	}
 }
@@ -89,10 +337,192 @@ func (s *SettingsService) ServiceStartup(ctx context.Context, options applicatio
 			return fmt.Errorf("failed to load settings: %w", err)
 		}
 	}
+	s.recordSavedHash()
+
+	go s.watchFile(ctx)
+
+	return nil
+}
+
+// watchFile watches settings.json for changes made outside Save() - a user
+// hand-editing the file, or a git pull of a shared config - and reloads it so
+// a running bouncer picks up the change without a restart. Writes debounce
+// for fileWatchDebounce so an editor's write-then-chmod (or a rename-over-file
+// atomic save) only triggers one reload.
+func (s *SettingsService) watchFile(ctx context.Context) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		slog.Error("Failed to create settings file watcher", "error", err)
+		return
+	}
+	defer watcher.Close()
+
+	// Watch the containing directory, not the file itself: an atomic save
+	// (write to a temp file, then rename over settings.json) replaces the
+	// watched inode, which would silently stop delivering events for a
+	// file-level watch.
+	dir := filepath.Dir(s.filePath)
+	if err := watcher.Add(dir); err != nil {
+		slog.Error("Failed to watch settings directory", "dir", dir, "error", err)
+		return
+	}
+
+	var debounce *time.Timer
+	defer func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != s.filePath {
+				continue
+			}
+			if event.Op&fsnotify.Remove != 0 {
+				// An atomic save removes the old inode before the rename
+				// lands; re-add the watch so we keep seeing the directory
+				// once the new file exists.
+				if err := watcher.Add(dir); err != nil {
+					slog.Warn("Failed to re-add settings directory watch", "dir", dir, "error", err)
+				}
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			if debounce == nil {
+				debounce = time.AfterFunc(fileWatchDebounce, func() {
+					if err := s.ReloadNow(); err != nil {
+						slog.Error("Failed to reload settings file", "error", err)
+					}
+				})
+			} else {
+				debounce.Reset(fileWatchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Error("Settings file watcher error", "error", err)
+		}
+	}
+}
+
+// ReloadNow reloads settings.json from disk immediately, diffs MCPServers
+// against the previously loaded set, and emits granular server:added,
+// server:removed, server:updated, server:enabled, and server:disabled events
+// for each change so the mcp.Server subsystem can reconcile individual
+// clients instead of bouncing every connection. It is exported so tests (and
+// the debounced file watcher) can trigger a reload synchronously.
+func (s *SettingsService) ReloadNow() error {
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read settings file: %w", err)
+	}
+
+	hash := sha256.Sum256(data)
+	s.watcherMutex.Lock()
+	unchanged := hash == s.lastSaved
+	s.watcherMutex.Unlock()
+	if unchanged {
+		// Our own Save() produced this write; nothing external changed.
+		return nil
+	}
+
+	s.mu.RLock()
+	previous := s.settings.MCPServers
+	s.mu.RUnlock()
+
+	var reloaded Settings
+	if err := json.Unmarshal(data, &reloaded); err != nil {
+		return fmt.Errorf("failed to parse settings file: %w", err)
+	}
+	normalizeMCPServers(reloaded.MCPServers)
+	if reloaded.LogFormat == "" {
+		reloaded.LogFormat = LogFormatText
+	}
+
+	s.mu.Lock()
+	s.settings = &reloaded
+	s.mu.Unlock()
+
+	s.watcherMutex.Lock()
+	s.lastSaved = hash
+	s.watcherMutex.Unlock()
+
+	slog.Info("Reloaded settings from an external file change", "file_path", s.filePath)
 
+	for _, event := range diffMCPServers(previous, reloaded.MCPServers) {
+		s.emitEvent(event.name, event.server)
+	}
+
+	s.mu.RLock()
+	current := s.settings
+	s.mu.RUnlock()
+	s.emitEvent("settings:updated", current)
 	return nil
 }
 
+// recordSavedHash stores the hash of the file currently on disk so the
+// watcher can tell its own writes apart from external edits.
+func (s *SettingsService) recordSavedHash() {
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		return
+	}
+	s.watcherMutex.Lock()
+	s.lastSaved = sha256.Sum256(data)
+	s.watcherMutex.Unlock()
+}
+
+// serverDiffEvent pairs an event name with the server it describes.
+type serverDiffEvent struct {
+	name   string
+	server MCPServerConfig
+}
+
+// diffMCPServers compares the previously loaded and newly reloaded server
+// lists by name and returns the granular events the change implies. A server
+// present in both lists is reported as enabled/disabled when Enabled flips,
+// updated when any other field changes, and otherwise produces no event.
+func diffMCPServers(previous, next []MCPServerConfig) []serverDiffEvent {
+	previousByName := make(map[string]MCPServerConfig, len(previous))
+	for _, server := range previous {
+		previousByName[server.Name] = server
+	}
+
+	var events []serverDiffEvent
+	seen := make(map[string]bool, len(next))
+	for _, server := range next {
+		seen[server.Name] = true
+		old, existed := previousByName[server.Name]
+		if !existed {
+			events = append(events, serverDiffEvent{EventServerAdded, server})
+			continue
+		}
+		switch {
+		case old.Enabled != server.Enabled && server.Enabled:
+			events = append(events, serverDiffEvent{EventServerEnabled, server})
+		case old.Enabled != server.Enabled && !server.Enabled:
+			events = append(events, serverDiffEvent{EventServerDisabled, server})
+		case !reflect.DeepEqual(old, server):
+			events = append(events, serverDiffEvent{EventServerUpdated, server})
+		}
+	}
+	for _, server := range previous {
+		if !seen[server.Name] {
+			events = append(events, serverDiffEvent{EventServerRemoved, server})
+		}
+	}
+	return events
+}
+
 // Subscribe sets the event callback
 func (s *SettingsService) Subscribe(callback func(e *application.CustomEvent)) {
 	s.callbacksMutex.Lock()
@@ -137,23 +567,48 @@ func (s *SettingsService) Load() error {
 		return err
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	err = json.Unmarshal(data, s.settings)
 	if err != nil {
 		return err
 	}
 
-	// Migrate existing configurations to include transport type
-	for i := range s.settings.MCPServers {
-		if s.settings.MCPServers[i].Transport == "" {
-			s.settings.MCPServers[i].Transport = TransportStdio
-		}
+	normalizeMCPServers(s.settings.MCPServers)
+	if s.settings.LogFormat == "" {
+		s.settings.LogFormat = LogFormatText
 	}
 
 	return nil
 }
 
+// normalizeMCPServers migrates existing configurations to include a
+// transport type, in place, for settings loaded from a file written before
+// TransportType existed.
+func normalizeMCPServers(servers []MCPServerConfig) {
+	for i := range servers {
+		if servers[i].Transport == "" {
+			servers[i].Transport = TransportStdio
+		}
+	}
+}
+
 // Save saves settings to file
 func (s *SettingsService) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.saveLocked()
+}
+
+// saveLocked does the actual marshal/write/event-emit for Save and the
+// mutators below. Callers must hold s.mu for writing.
+func (s *SettingsService) saveLocked() error {
+	if s.filePath == "" {
+		// Not started yet (no settings file chosen): keep changes in memory.
+		s.emitEvent("settings:updated", s.settings)
+		return nil
+	}
 	slog.Debug("Saving settings", "file_path", s.filePath, "server_count", len(s.settings.MCPServers))
 
 	data, err := json.MarshalIndent(s.settings, "", "  ")
@@ -166,7 +621,9 @@ func (s *SettingsService) Save() error {
 		slog.Error("Failed to write settings file", "file_path", s.filePath, "error", err)
 		return err
 	}
-
+	s.watcherMutex.Lock()
+	s.lastSaved = sha256.Sum256(data)
+	s.watcherMutex.Unlock()
 	slog.Debug("Settings saved successfully", "file_path", s.filePath)
 
 	// Emit settings updated event
@@ -176,19 +633,26 @@ func (s *SettingsService) Save() error {
 
 // GetSettings returns the current settings
 func (s *SettingsService) GetSettings() *Settings {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.settings
 }
 
 // UpdateSettings updates the settings and saves them
 func (s *SettingsService) UpdateSettings(settings *Settings) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.settings = settings
-	return s.Save()
+	return s.saveLocked()
 }
 
 // AddMCPServer adds a new MCP server configuration
 func (s *SettingsService) AddMCPServer(config MCPServerConfig) error {
 	slog.Info("Adding MCP server", "name", config.Name, "command", config.Command)
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// Check for duplicate names
 	for _, server := range s.settings.MCPServers {
 		if server.Name == config.Name {
@@ -197,7 +661,7 @@ func (s *SettingsService) AddMCPServer(config MCPServerConfig) error {
 	}
 
 	s.settings.MCPServers = append(s.settings.MCPServers, config)
-	if err := s.Save(); err != nil {
+	if err := s.saveLocked(); err != nil {
 		slog.Error("Failed to save settings after adding server", "error", err)
 		return err
 	}
@@ -207,10 +671,13 @@ func (s *SettingsService) AddMCPServer(config MCPServerConfig) error {
 
 // RemoveMCPServer removes an MCP server configuration by name
 func (s *SettingsService) RemoveMCPServer(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	for i, server := range s.settings.MCPServers {
 		if server.Name == name {
 			s.settings.MCPServers = append(s.settings.MCPServers[:i], s.settings.MCPServers[i+1:]...)
-			return s.Save()
+			return s.saveLocked()
 		}
 	}
 	return fmt.Errorf("server '%s' not found", name)
@@ -218,6 +685,9 @@ func (s *SettingsService) RemoveMCPServer(name string) error {
 
 // UpdateMCPServer updates an existing MCP server configuration
 func (s *SettingsService) UpdateMCPServer(name string, config MCPServerConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	// Check for duplicate names (excluding the current server being updated)
 	for _, server := range s.settings.MCPServers {
 		if server.Name == config.Name && server.Name != name {
@@ -228,7 +698,7 @@ func (s *SettingsService) UpdateMCPServer(name string, config MCPServerConfig) e
 	for i, server := range s.settings.MCPServers {
 		if server.Name == name {
 			s.settings.MCPServers[i] = config
-			return s.Save()
+			return s.saveLocked()
 		}
 	}
 	return fmt.Errorf("server '%s' not found", name)
@@ -236,11 +706,16 @@ func (s *SettingsService) UpdateMCPServer(name string, config MCPServerConfig) e
 
 // GetMCPServers returns all MCP server configurations
 func (s *SettingsService) GetMCPServers() []MCPServerConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.settings.MCPServers
 }
 
 // GetEnabledMCPServers returns only enabled MCP server configurations
 func (s *SettingsService) GetEnabledMCPServers() []MCPServerConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	var enabled []MCPServerConfig
 	for _, server := range s.settings.MCPServers {
 		if server.Enabled {
@@ -252,23 +727,221 @@ func (s *SettingsService) GetEnabledMCPServers() []MCPServerConfig {
 
 // SetListenAddr updates the listen address
 func (s *SettingsService) SetListenAddr(addr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.settings.ListenAddr = addr
-	return s.Save()
+	return s.saveLocked()
 }
 
 // GetListenAddr returns the current listen address
 func (s *SettingsService) GetListenAddr() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.settings.ListenAddr
 }
 
+// SetAPIListenAddr updates the REST API's listen address
+func (s *SettingsService) SetAPIListenAddr(addr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings.APIListenAddr = addr
+	return s.saveLocked()
+}
+
+// GetAPIListenAddr returns the configured REST API listen address, defaulting
+// to ":8080" when unset.
+func (s *SettingsService) GetAPIListenAddr() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.settings.APIListenAddr == "" {
+		return ":8080"
+	}
+	return s.settings.APIListenAddr
+}
+
+// SetGRPCListenAddr updates the BouncerService gRPC server's listen address.
+func (s *SettingsService) SetGRPCListenAddr(addr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings.GRPCListenAddr = addr
+	return s.saveLocked()
+}
+
+// GetGRPCListenAddr returns the configured BouncerService gRPC listen
+// address, defaulting to ":50051" when unset.
+func (s *SettingsService) GetGRPCListenAddr() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.settings.GRPCListenAddr == "" {
+		return ":50051"
+	}
+	return s.settings.GRPCListenAddr
+}
+
+// TLSConfig is the REST server's TLS settings, as returned by GetTLSConfig.
+type TLSConfig struct {
+	// CertFile and KeyFile are PEM file paths. Enabled reports whether both
+	// are set; pkg/api treats a disabled TLSConfig as "serve plain HTTP".
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile is an optional PEM bundle of CAs trusted to sign client
+	// certificates for mTLS.
+	ClientCAFile string
+	// ClientAuthMode controls how ClientCAFile is enforced.
+	ClientAuthMode TLSClientAuthMode
+}
+
+// Enabled reports whether both halves of the certificate/key pair are
+// configured, i.e. whether the REST server should serve TLS at all.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// GetTLSConfig returns the REST server's TLS settings.
+func (s *SettingsService) GetTLSConfig() TLSConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	mode := s.settings.TLSClientAuthMode
+	if mode == "" {
+		mode = TLSClientAuthNone
+	}
+	return TLSConfig{
+		CertFile:       s.settings.TLSCertFile,
+		KeyFile:        s.settings.TLSKeyFile,
+		ClientCAFile:   s.settings.TLSClientCAFile,
+		ClientAuthMode: mode,
+	}
+}
+
+// SetTLSConfig persists the REST server's TLS settings.
+func (s *SettingsService) SetTLSConfig(cfg TLSConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings.TLSCertFile = cfg.CertFile
+	s.settings.TLSKeyFile = cfg.KeyFile
+	s.settings.TLSClientCAFile = cfg.ClientCAFile
+	s.settings.TLSClientAuthMode = cfg.ClientAuthMode
+	return s.saveLocked()
+}
+
+// GetLogFormat returns the configured log output format
+func (s *SettingsService) GetLogFormat() LogFormat {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.settings.LogFormat == "" {
+		return LogFormatText
+	}
+	return s.settings.LogFormat
+}
+
+// GetACLPolicies returns the configured ACL policies, if any
+func (s *SettingsService) GetACLPolicies() []acl.Policy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.settings.ACLPolicies
+}
+
+// GetToolPolicy returns the configured tool exposure policy, if any
+func (s *SettingsService) GetToolPolicy() toolpolicy.Policy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.settings.ToolPolicy
+}
+
+// SetToolPolicy updates the tool exposure policy and saves it, emitting
+// "settings:updated" so a running bouncer can reconcile without a restart
+func (s *SettingsService) SetToolPolicy(policy toolpolicy.Policy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings.ToolPolicy = policy
+	return s.saveLocked()
+}
+
+// GetTokenStoreMode returns the configured token storage mode, defaulting to
+// TokenStoreAuto when unset.
+func (s *SettingsService) GetTokenStoreMode() TokenStoreMode {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.settings.TokenStoreMode == "" {
+		return TokenStoreAuto
+	}
+	return s.settings.TokenStoreMode
+}
+
+// SetTokenStoreMode updates the token storage mode and saves it
+func (s *SettingsService) SetTokenStoreMode(mode TokenStoreMode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings.TokenStoreMode = mode
+	return s.saveLocked()
+}
+
+// GetMetricsEnabled returns whether the /metrics endpoint should be served.
+func (s *SettingsService) GetMetricsEnabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.settings.MetricsEnabled
+}
+
+// SetMetricsEnabled updates whether the /metrics endpoint is served and saves it
+func (s *SettingsService) SetMetricsEnabled(enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings.MetricsEnabled = enabled
+	return s.saveLocked()
+}
+
+// AddAPIToken appends token to the store and saves it. Callers are expected
+// to have already hashed the raw secret into token.HashedToken; settings
+// never sees or stores the secret itself.
+func (s *SettingsService) AddAPIToken(token APIToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, existing := range s.settings.APITokens {
+		if existing.ID == token.ID {
+			return fmt.Errorf("token with id '%s' already exists", token.ID)
+		}
+	}
+	s.settings.APITokens = append(s.settings.APITokens, token)
+	return s.saveLocked()
+}
+
+// RemoveAPIToken deletes the token with the given id.
+func (s *SettingsService) RemoveAPIToken(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, token := range s.settings.APITokens {
+		if token.ID == id {
+			s.settings.APITokens = append(s.settings.APITokens[:i], s.settings.APITokens[i+1:]...)
+			return s.saveLocked()
+		}
+	}
+	return fmt.Errorf("token '%s' not found", id)
+}
+
+// GetAPITokens returns every registered API token, hashes only.
+func (s *SettingsService) GetAPITokens() []APIToken {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.settings.APITokens
+}
+
 // SetAutoStart updates the auto-start setting
 func (s *SettingsService) SetAutoStart(autoStart bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.settings.AutoStart = autoStart
-	return s.Save()
+	return s.saveLocked()
 }
 
 // GetAutoStart returns the current auto-start setting
 func (s *SettingsService) GetAutoStart() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.settings.AutoStart
 }
 